@@ -1,10 +1,10 @@
 package ciinfo
 
 import (
-	"os"
+	"strconv"
 	"strings"
-	"sync"
 
+	"github.com/startracex/ciinfo/syntax"
 	"github.com/startracex/ciinfo/vendors"
 )
 
@@ -14,6 +14,60 @@ type Info struct {
 	ID      string
 	Name    string
 	Vendors map[string]bool
+	// Chain lists the detected vendors from the outermost orchestrator to
+	// the innermost build tool. ID and Name describe the last, primary one.
+	Chain       []string
+	Meta        Meta
+	PullRequest PullRequest
+	Confidence  float64
+	Event       vendors.Event
+}
+
+type Meta struct {
+	Commit      string
+	Branch      string
+	Tag         string
+	BuildNumber string
+	BuildID     string
+	JobID       string
+	BuildURL    string
+	JobURL      string
+	Repo        string
+}
+
+type PullRequest struct {
+	Number       int
+	SourceBranch string
+	TargetBranch string
+	HeadSHA      string
+	BaseSHA      string
+}
+
+func (m *Meta) merge(o Meta) {
+	set(&m.Commit, o.Commit)
+	set(&m.Branch, o.Branch)
+	set(&m.Tag, o.Tag)
+	set(&m.BuildNumber, o.BuildNumber)
+	set(&m.BuildID, o.BuildID)
+	set(&m.JobID, o.JobID)
+	set(&m.BuildURL, o.BuildURL)
+	set(&m.JobURL, o.JobURL)
+	set(&m.Repo, o.Repo)
+}
+
+func (p *PullRequest) merge(o PullRequest) {
+	set(&p.Number, o.Number)
+	set(&p.SourceBranch, o.SourceBranch)
+	set(&p.TargetBranch, o.TargetBranch)
+	set(&p.HeadSHA, o.HeadSHA)
+	set(&p.BaseSHA, o.BaseSHA)
+}
+
+func set[T comparable](dst *T, v T) {
+	var zero T
+	if v != zero {
+		*dst = v
+	}
 }
 
 func EnvironMap(env []string) map[string]string {
@@ -26,14 +80,25 @@ func EnvironMap(env []string) map[string]string {
 	return out
 }
 
-var GetInfo = sync.OnceValue(
-	func() Info {
-		return GetInfoFrom(EnvironMap(os.Environ()), vendors.All)
-	},
-)
+func GetInfoFrom(env map[string]string, vs []vendors.Vendor) Info {
+	return detect(syntax.Map(env), vs, false)
+}
 
-func GetInfoFrom(env map[string]string, vendors []vendors.Vendor) Info {
-	if isExplicitlyFalseLike(env["CI"]) {
+// GetInfoFromEnv is like GetInfoFrom but reads variables from any
+// Environment, such as syntax.LookupFunc(os.LookupEnv), without copying it.
+func GetInfoFromEnv(env syntax.Environment, vs []vendors.Vendor) Info {
+	return detect(env, vs, false)
+}
+
+// GetInfoFromStrict is like GetInfoFrom but ignores vendor matches that none
+// of the vendor's Corroborate variables back up, and only falls back to the
+// common keys when at least two of them are set.
+func GetInfoFromStrict(env map[string]string, vs []vendors.Vendor) Info {
+	return detect(syntax.Map(env), vs, true)
+}
+
+func detect(env syntax.Environment, vs []vendors.Vendor, strict bool) Info {
+	if ci, _ := env.Lookup("CI"); isExplicitlyFalseLike(ci) {
 		return Info{}
 	}
 
@@ -41,47 +106,107 @@ func GetInfoFrom(env map[string]string, vendors []vendors.Vendor) Info {
 		Vendors: make(map[string]bool, 2),
 	}
 
-	for _, vendor := range vendors {
+	var matched []vendors.Vendor
+	for _, vendor := range vs {
 		if !vendor.Env.Match(env) {
 			continue
 		}
+		if strict && !corroborated(&vendor, env) {
+			continue
+		}
+		matched = append(matched, vendor)
+	}
 
+	// Vendors run from the outermost to the innermost. Each one overrides
+	// only what it knows: IsPR when it has a PR rule, and the Meta,
+	// PullRequest and Event values it finds; the rest falls back to the
+	// vendors it runs in.
+	for _, vendor := range vendors.SortByNesting(matched) {
 		info.Vendors[vendor.Constant] = true
+		info.Chain = append(info.Chain, vendor.Constant)
 		info.IsCI = true
 		info.Name = vendor.Name
 		info.ID = vendor.Constant
+		info.Confidence = vendorConfidence(&vendor, env)
 
 		if vendor.PR != nil {
 			info.IsPR = vendor.PR.Match(env)
+			if !info.IsPR {
+				info.PullRequest = PullRequest{}
+			}
 		}
+
+		if vendor.Meta != nil {
+			info.Meta.merge(metaFrom(vendor.Meta, env))
+		}
+
+		if info.IsPR && vendor.PullRequest != nil {
+			info.PullRequest.merge(pullRequestFrom(vendor.PullRequest, env))
+		}
+
+		if event := vendor.ClassifyEvent(env); event != vendors.EventUnknown {
+			info.Event = event
+		}
+	}
+
+	switch {
+	case info.Event == vendors.EventUnknown && info.IsPR:
+		info.Event = vendors.EventPullRequest
+	case info.Event == vendors.EventPush && info.Meta.Tag != "":
+		info.Event = vendors.EventTag
 	}
 
 	if !info.IsCI {
-		info.IsCI = fromCommonKeys(env)
+		info.Confidence = fallbackConfidence(env)
+		info.IsCI = info.Confidence > 0 && (!strict || info.Confidence >= neutralConfidence)
 	}
 
 	return info
 }
 
-var commonKeys = []string{
-	"BUILD_ID",
-	"BUILD_NUMBER",
-	"CI",
-	"CI_APP_ID",
-	"CI_BUILD_ID",
-	"CI_BUILD_NUMBER",
-	"CI_NAME",
-	"CONTINUOUS_INTEGRATION",
-	"RUN_ID",
+func metaFrom(m *vendors.Meta, env syntax.Environment) Meta {
+	return Meta{
+		Commit:      m.Commit.Value(env),
+		Branch:      m.Branch.Value(env),
+		Tag:         m.Tag.Value(env),
+		BuildNumber: m.BuildNumber.Value(env),
+		BuildID:     m.BuildID.Value(env),
+		JobID:       m.JobID.Value(env),
+		BuildURL:    m.BuildURL.Value(env),
+		JobURL:      m.JobURL.Value(env),
+		Repo:        m.Repo.Value(env),
+	}
+}
+
+func pullRequestFrom(p *vendors.PullRequest, env syntax.Environment) PullRequest {
+	return PullRequest{
+		Number:       parsePRNumber(p.Number.Value(env)),
+		SourceBranch: p.SourceBranch.Value(env),
+		TargetBranch: p.TargetBranch.Value(env),
+		HeadSHA:      p.HeadSHA.Value(env),
+		BaseSHA:      p.BaseSHA.Value(env),
+	}
+}
+
+// parsePRNumber accepts a bare number as well as the URL or ref forms some
+// vendors expose, such as "https://github.com/o/r/pull/12" or "12/merge".
+func parsePRNumber(s string) int {
+	segments := strings.Split(s, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if n, err := strconv.Atoi(strings.TrimLeft(segments[i], "#!")); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
 }
 
-func fromCommonKeys(env map[string]string) bool {
-	for _, k := range commonKeys {
-		if env[k] != "" {
-			return true
+func commonKey(env syntax.Environment) string {
+	for _, k := range vendors.CommonKeys() {
+		if v, _ := env.Lookup(k); v != "" {
+			return k
 		}
 	}
-	return false
+	return ""
 }
 
 func isExplicitlyFalseLike(s string) bool {
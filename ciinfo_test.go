@@ -147,3 +147,359 @@ func TestGetInfoFrom_MultipleVendorsLastWins(t *testing.T) {
 		t.Errorf("Vendors map = %+v, want %+v", info.Vendors, expectedVendors)
 	}
 }
+
+func TestGetInfoFrom_Meta(t *testing.T) {
+	vlist := []vendors.Vendor{
+		{
+			Name:     "TestCI",
+			Constant: "TEST",
+			Env: syntax.EnvList{
+				{StrictEqual: "TEST_ENV"},
+			},
+			Meta: &vendors.Meta{
+				Commit:   syntax.Extract{{Env: "TEST_SHA"}},
+				Branch:   syntax.Extract{{Env: "TEST_REF", TrimPrefix: "refs/heads/"}},
+				BuildURL: syntax.Extract{{Template: "https://ci/${TEST_RUN}"}},
+			},
+		},
+	}
+
+	env := map[string]string{
+		"TEST_ENV": "1",
+		"TEST_SHA": "abc123",
+		"TEST_REF": "refs/heads/main",
+		"TEST_RUN": "42",
+	}
+
+	info := GetInfoFrom(env, vlist)
+	want := Meta{
+		Commit:   "abc123",
+		Branch:   "main",
+		BuildURL: "https://ci/42",
+	}
+	if info.Meta != want {
+		t.Errorf("Meta = %+v, want %+v", info.Meta, want)
+	}
+}
+
+func TestGetInfoFrom_MetaGitHubActions(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_SHA":        "ffac537e6cbbf934b08745a378932722df287a53",
+		"GITHUB_REF_NAME":   "v1.2.0",
+		"GITHUB_REF_TYPE":   "tag",
+		"GITHUB_RUN_ID":     "1658821493",
+		"GITHUB_RUN_NUMBER": "7",
+		"GITHUB_JOB":        "build",
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_REPOSITORY": "octo/hello",
+	}
+
+	info := GetInfoFrom(env, []vendors.Vendor{vendors.VendorGITHUB_ACTIONS})
+	want := Meta{
+		Commit:      "ffac537e6cbbf934b08745a378932722df287a53",
+		Tag:         "v1.2.0",
+		BuildNumber: "7",
+		BuildID:     "1658821493",
+		JobID:       "build",
+		BuildURL:    "https://github.com/octo/hello/actions/runs/1658821493",
+		Repo:        "octo/hello",
+	}
+	if info.Meta != want {
+		t.Errorf("Meta = %+v, want %+v", info.Meta, want)
+	}
+}
+
+func TestGetInfoFrom_MetaRefPrefix(t *testing.T) {
+	env := map[string]string{
+		"TF_BUILD":           "True",
+		"BUILD_SOURCEBRANCH": "refs/heads/feature/refs/tags/x",
+	}
+
+	info := GetInfoFrom(env, []vendors.Vendor{vendors.VendorAZURE_PIPELINES})
+	if info.Meta.Branch != "feature/refs/tags/x" || info.Meta.Tag != "" {
+		t.Errorf("Branch = %q, Tag = %q, want a branch only", info.Meta.Branch, info.Meta.Tag)
+	}
+}
+
+func TestGetInfoFrom_PullRequest(t *testing.T) {
+	env := map[string]string{
+		"GITLAB_CI":                           "true",
+		"CI_MERGE_REQUEST_ID":                 "3001",
+		"CI_MERGE_REQUEST_IID":                "17",
+		"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature",
+		"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "main",
+		"CI_MERGE_REQUEST_DIFF_BASE_SHA":      "b45e",
+		"CI_COMMIT_SHA":                       "c0ff",
+	}
+
+	info := GetInfoFrom(env, []vendors.Vendor{vendors.VendorGITLAB})
+	want := PullRequest{
+		Number:       17,
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		HeadSHA:      "c0ff",
+		BaseSHA:      "b45e",
+	}
+	if info.PullRequest != want {
+		t.Errorf("PullRequest = %+v, want %+v", info.PullRequest, want)
+	}
+}
+
+func TestGetInfoFrom_PullRequestNotPR(t *testing.T) {
+	env := map[string]string{
+		"TRAVIS":              "true",
+		"TRAVIS_PULL_REQUEST": "false",
+		"TRAVIS_BRANCH":       "main",
+	}
+
+	info := GetInfoFrom(env, []vendors.Vendor{vendors.VendorTRAVIS})
+	if info.IsPR {
+		t.Error("IsPR should be false")
+	}
+	if info.PullRequest != (PullRequest{}) {
+		t.Errorf("PullRequest should be empty, got %+v", info.PullRequest)
+	}
+}
+
+func TestParsePRNumber(t *testing.T) {
+	tests := map[string]int{
+		"42":                              42,
+		"https://github.com/o/r/pull/123": 123,
+		"https://gitlab.com/g/p/-/merge_requests/9": 9,
+		"7/merge": 7,
+		"pr/15":   15,
+		"false":   0,
+		"":        0,
+	}
+
+	for in, want := range tests {
+		if got := parsePRNumber(in); got != want {
+			t.Errorf("parsePRNumber(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestGetInfoFrom_CatalogPriority(t *testing.T) {
+	c := vendors.NewCatalog(vendors.All...)
+	err := c.Register(vendors.Vendor{
+		Name:     "In-house CI",
+		Constant: "INHOUSE",
+		Env: syntax.EnvList{
+			{StrictEqual: "INHOUSE_CI"},
+		},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"INHOUSE_CI": "1",
+		"TRAVIS":     "true",
+	}
+
+	info := GetInfoFrom(env, c.Vendors())
+	if info.ID != "INHOUSE" {
+		t.Errorf("ID = %q, want INHOUSE", info.ID)
+	}
+	if !info.Vendors["TRAVIS"] {
+		t.Error("TRAVIS should still be reported in Vendors")
+	}
+}
+
+func TestGetInfoFrom_NestedChain(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_EVENT_NAME": "pull_request",
+		"GITHUB_SHA":        "abc",
+		"EARTHLY_CI":        "true",
+	}
+
+	info := GetInfoFrom(env, vendors.All)
+	if !reflect.DeepEqual(info.Chain, []string{"GITHUB_ACTIONS", "EARTHLY"}) {
+		t.Errorf("Chain = %v, want [GITHUB_ACTIONS EARTHLY]", info.Chain)
+	}
+	if info.ID != "EARTHLY" {
+		t.Errorf("ID = %q, want the inner vendor EARTHLY", info.ID)
+	}
+	if !info.IsPR || info.Meta.Commit != "abc" {
+		t.Errorf("outer vendor details should carry through: IsPR=%v Meta=%+v", info.IsPR, info.Meta)
+	}
+}
+
+func TestGetInfoFrom_NestedPullRequest(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_EVENT_NAME": "pull_request",
+		"GITHUB_REF":        "refs/pull/17/merge",
+		"GITHUB_HEAD_REF":   "feature",
+		"GITHUB_BASE_REF":   "main",
+		"GITHUB_SHA":        "abc",
+		"EARTHLY_CI":        "true",
+	}
+
+	info := GetInfoFrom(env, vendors.All)
+	want := PullRequest{Number: 17, SourceBranch: "feature", TargetBranch: "main"}
+	if info.ID != "EARTHLY" || !info.IsPR || info.PullRequest != want {
+		t.Errorf("ID=%q IsPR=%v PullRequest=%+v, want EARTHLY with the outer pull request %+v", info.ID, info.IsPR, info.PullRequest, want)
+	}
+	if info.Event != vendors.EventPullRequest {
+		t.Errorf("Event = %q, want the outer %q", info.Event, vendors.EventPullRequest)
+	}
+
+	// An inner vendor with its own PR rule decides IsPR, and a negative
+	// answer drops the outer pull request details.
+	inner := vendors.Vendor{
+		Constant: "INNER",
+		Env:      syntax.EnvList{{StrictEqual: "INNER_CI"}},
+		PR:       &syntax.PR{StrictEqual: "INNER_PR"},
+		Meta:     &vendors.Meta{Branch: syntax.Extract{{Env: "INNER_BRANCH"}}},
+		NestedIn: []string{"GITHUB_ACTIONS"},
+	}
+	env["INNER_CI"] = "true"
+	env["INNER_BRANCH"] = "inner"
+	info = GetInfoFrom(env, []vendors.Vendor{vendors.VendorGITHUB_ACTIONS, inner})
+	if info.IsPR || info.PullRequest != (PullRequest{}) {
+		t.Errorf("IsPR=%v PullRequest=%+v, want no pull request", info.IsPR, info.PullRequest)
+	}
+	if info.Meta.Branch != "inner" || info.Meta.Commit != "abc" {
+		t.Errorf("Meta = %+v, want the inner branch and the outer commit", info.Meta)
+	}
+}
+
+func TestGetInfoFrom_Event(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want vendors.Event
+	}{
+		{
+			name: "vendor rule",
+			env:  map[string]string{"GITHUB_ACTIONS": "true", "GITHUB_EVENT_NAME": "schedule"},
+			want: vendors.EventSchedule,
+		},
+		{
+			name: "push of a tag",
+			env:  map[string]string{"TRAVIS": "true", "TRAVIS_EVENT_TYPE": "push", "TRAVIS_TAG": "v1.0.0"},
+			want: vendors.EventTag,
+		},
+		{
+			name: "pull request without event rules",
+			env:  map[string]string{"NETLIFY": "true", "PULL_REQUEST": "true"},
+			want: vendors.EventPullRequest,
+		},
+		{
+			name: "unknown",
+			env:  map[string]string{"NETLIFY": "true", "PULL_REQUEST": "false"},
+			want: vendors.EventUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetInfoFrom(tt.env, vendors.All).Event; got != tt.want {
+				t.Errorf("Event = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironMapKeepsEmptyValues(t *testing.T) {
+	env := EnvironMap([]string{"EMPTY=", "SET=1", "NOEQUALS"})
+	if v, ok := env["EMPTY"]; !ok || v != "" {
+		t.Errorf("EMPTY = %q, %v; want defined and empty", v, ok)
+	}
+	if _, ok := env["NOEQUALS"]; ok {
+		t.Error("entries without '=' should be skipped")
+	}
+}
+
+func TestEmptyVersusUnset(t *testing.T) {
+	env := syntax.Map(EnvironMap([]string{"EMPTY=", "SET=1", "NOEQUALS"}))
+	tests := []struct {
+		name string
+		rule syntax.Env
+		want bool
+	}{
+		{"any empty", syntax.Env{EqualsAnyOf: []string{"EMPTY"}}, false},
+		{"any unset", syntax.Env{EqualsAnyOf: []string{"NOEQUALS"}}, false},
+		{"any set", syntax.Env{EqualsAnyOf: []string{"EMPTY", "SET"}}, true},
+		{"any defined", syntax.Env{Any: []syntax.Env{{StrictEqual: "EMPTY", Predicates: syntax.Predicates{Defined: true}}}}, true},
+		{"any defined unset", syntax.Env{Any: []syntax.Env{{StrictEqual: "NOEQUALS", Predicates: syntax.Predicates{Defined: true}}}}, false},
+		{"equals empty", syntax.Env{EqualsMap: map[string]string{"EMPTY": ""}}, true},
+		{"equals empty unset", syntax.Env{EqualsMap: map[string]string{"NOEQUALS": ""}}, false},
+		{"equals empty set", syntax.Env{EqualsMap: map[string]string{"SET": ""}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Match(env); got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetInfoFromEnv(t *testing.T) {
+	env := syntax.Overlay{
+		syntax.Map{"CI": "false"},
+		syntax.Environ{"GITHUB_ACTIONS=true"},
+	}
+	if info := GetInfoFromEnv(env, vendors.All); info.IsCI {
+		t.Errorf("overlay CI=false should disable detection, got %+v", info)
+	}
+
+	info := GetInfoFromEnv(env[1:], vendors.All)
+	if !info.IsCI || info.ID != "GITHUB_ACTIONS" {
+		t.Errorf("got %+v, want GitHub Actions", info)
+	}
+}
+
+func TestGetInfoFrom_PullRequestTarget(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_EVENT_NAME": "pull_request_target",
+		"GITHUB_REF":        "refs/pull/3/merge",
+	}
+	info := GetInfoFrom(env, vendors.All)
+	if !info.IsPR || info.Event != vendors.EventPullRequest || info.PullRequest.Number != 3 {
+		t.Errorf("IsPR = %v, Event = %s, PullRequest = %+v, want pull request 3", info.IsPR, info.Event, info.PullRequest)
+	}
+}
+
+// Every event rule classifying a pull request must be able to hold together
+// with the vendor's PR rule, and every other one without it, so that Event
+// and IsPR agree.
+func TestGetInfoFrom_EventAgreesWithIsPR(t *testing.T) {
+	for _, v := range vendors.All {
+		if v.PR == nil {
+			continue
+		}
+		for _, rule := range v.Events {
+			whens := []syntax.PR{rule.When}
+			if rule.When.StrictEqual != "" && len(rule.When.EqualsAnyOf) > 1 {
+				whens = nil
+				for _, val := range rule.When.EqualsAnyOf {
+					whens = append(whens, syntax.PR{StrictEqual: rule.When.StrictEqual, EqualsAnyOf: []string{val}})
+				}
+			}
+			for _, when := range whens {
+				match, nomatch := []syntax.Rule{&v.Env, &when, v.PR}, []syntax.Rule(nil)
+				if rule.Event != vendors.EventPullRequest {
+					match, nomatch = match[:2], match[2:]
+				}
+				env, ok := syntax.Solve(match, nomatch)
+				if !ok {
+					t.Errorf("%s: no environment for %s", v.Constant, when)
+					continue
+				}
+				info := GetInfoFrom(env, []vendors.Vendor{v})
+				if info.Event != rule.Event {
+					continue // a later rule wins in this environment
+				}
+				if (info.Event == vendors.EventPullRequest) != info.IsPR {
+					t.Errorf("%s with %v: Event = %s, IsPR = %v", v.Constant, env, info.Event, info.IsPR)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Package ciinfotest simulates CI vendors for tests of code that behaves
+// differently in CI.
+package ciinfotest
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/startracex/ciinfo"
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+type config struct {
+	pr  bool
+	set map[string]string
+}
+
+type Option func(*config)
+
+// PR also satisfies the vendor's pull request rule and fills its pull request
+// details.
+func PR() Option {
+	return func(c *config) { c.pr = true }
+}
+
+// Set sets key to value after the vendor's rules have been satisfied.
+func Set(key, value string) Option {
+	return func(c *config) {
+		if c.set == nil {
+			c.set = map[string]string{}
+		}
+		c.set[key] = value
+	}
+}
+
+// Env returns an environment in which the vendor with the given constant is
+// detected. Build metadata such as the commit and branch is filled with
+// plausible values.
+func Env(constant string, opts ...Option) (map[string]string, error) {
+	i := slices.IndexFunc(vendors.All, func(v vendors.Vendor) bool { return v.Constant == constant })
+	if i < 0 {
+		return nil, fmt.Errorf("ciinfotest: unknown vendor %q", constant)
+	}
+	v := &vendors.All[i]
+
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	env := map[string]string{}
+	fillMeta(env, v.Meta)
+	if c.pr {
+		fillPullRequest(env, v.PullRequest)
+	}
+	satisfy(env, &v.Env)
+	if c.pr && v.PR != nil {
+		satisfy(env, v.PR)
+	}
+	if _, ok := env["CI"]; !ok {
+		env["CI"] = "true"
+	}
+	for k, val := range c.set {
+		env[k] = val
+	}
+	return env, nil
+}
+
+// Simulate clears every variable ciinfo reads, sets the environment returned
+// by Env with t.Setenv and resets the default detector, so ciinfo.GetInfo
+// reports the vendor. The detector is reset again when the test ends.
+func Simulate(t testing.TB, constant string, opts ...Option) map[string]string {
+	t.Helper()
+	env, err := Env(constant, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Clear(t)
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	ciinfo.Reset()
+	return env
+}
+
+// Clear unsets every variable ciinfo reads for the rest of the test, as on a
+// developer machine. The default detector is reset to read the process
+// environment and restored when the test ends.
+func Clear(t testing.TB) {
+	t.Helper()
+	prev := ciinfo.Default()
+	ciinfo.SetDefault(nil)
+	t.Cleanup(func() { ciinfo.SetDefault(prev) })
+	for _, k := range Keys() {
+		if _, ok := os.LookupEnv(k); ok {
+			// t.Setenv restores the original value when the test ends.
+			t.Setenv(k, "")
+			os.Unsetenv(k)
+		}
+	}
+}
+
+// Keys returns every variable read by the rules and metadata of vendors.All,
+// and by the generic fallback.
+func Keys() []string {
+	keys := vendors.CommonKeys()
+	add := func(ks ...string) {
+		for _, k := range ks {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	for i := range vendors.All {
+		v := &vendors.All[i]
+		add(v.Env.Keys()...)
+		if v.PR != nil {
+			add(v.PR.Keys()...)
+		}
+		add(v.Corroborate...)
+		for j := range v.Events {
+			add(v.Events[j].When.Keys()...)
+		}
+		for _, x := range extracts(v) {
+			add(extractKeys(x)...)
+		}
+	}
+	return keys
+}
+
+func extracts(v *vendors.Vendor) []*syntax.Extract {
+	var xs []*syntax.Extract
+	if m := v.Meta; m != nil {
+		xs = append(xs, &m.Commit, &m.Branch, &m.Tag, &m.BuildNumber, &m.BuildID, &m.JobID, &m.BuildURL, &m.JobURL, &m.Repo)
+	}
+	if p := v.PullRequest; p != nil {
+		xs = append(xs, &p.Number, &p.SourceBranch, &p.TargetBranch, &p.HeadSHA, &p.BaseSHA)
+	}
+	return xs
+}
+
+func extractKeys(x *syntax.Extract) []string {
+	var keys []string
+	for _, s := range *x {
+		if s.Env != "" {
+			keys = append(keys, s.Env)
+		}
+		os.Expand(s.Template, func(k string) string {
+			keys = append(keys, k)
+			return ""
+		})
+		keys = append(keys, s.If.Keys()...)
+	}
+	return keys
+}
+
+const (
+	sha     = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	baseSHA = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+)
+
+func fillMeta(env map[string]string, m *vendors.Meta) {
+	if m == nil {
+		return
+	}
+	fill(env, m.Commit, sha)
+	fill(env, m.Branch, "main")
+	fill(env, m.BuildNumber, "42")
+	fill(env, m.BuildID, "42")
+	fill(env, m.JobID, "7")
+	fill(env, m.BuildURL, "https://ci.example.com/builds/42")
+	fill(env, m.JobURL, "https://ci.example.com/builds/42/jobs/7")
+	fill(env, m.Repo, "example/project")
+}
+
+func fillPullRequest(env map[string]string, p *vendors.PullRequest) {
+	if p == nil {
+		return
+	}
+	fill(env, p.Number, "17")
+	fill(env, p.SourceBranch, "feature")
+	fill(env, p.TargetBranch, "main")
+	fill(env, p.HeadSHA, sha)
+	fill(env, p.BaseSHA, baseSHA)
+}
+
+// fill sets the variable of the first unconditional source of x, keeping
+// any prefix the source trims.
+func fill(env map[string]string, x syntax.Extract, value string) {
+	for _, s := range x {
+		if s.Env == "" || len(s.If) > 0 {
+			continue
+		}
+		if _, ok := env[s.Env]; !ok {
+			env[s.Env] = s.TrimPrefix + value
+		}
+		return
+	}
+}
+
+type rule interface {
+	Match(env syntax.Environment) bool
+	Satisfy() (map[string]string, bool)
+}
+
+// satisfy sets what r needs to match, unless the plausible values already
+// in env are enough.
+func satisfy(env map[string]string, r rule) {
+	if r.Match(syntax.Map(env)) {
+		return
+	}
+	want, _ := r.Satisfy()
+	for k, v := range want {
+		env[k] = v
+	}
+}
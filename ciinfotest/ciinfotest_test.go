@@ -0,0 +1,83 @@
+package ciinfotest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/startracex/ciinfo"
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+func TestEnv(t *testing.T) {
+	for _, v := range vendors.All {
+		t.Run(v.Constant, func(t *testing.T) {
+			env, err := Env(v.Constant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := ciinfo.GetInfoFrom(env, vendors.All)
+			if info.ID != v.Constant || info.IsPR {
+				t.Errorf("GetInfoFrom(%v) = ID %q, IsPR %v, want %q without PR", env, info.ID, info.IsPR, v.Constant)
+			}
+
+			env, err = Env(v.Constant, PR())
+			if err != nil {
+				t.Fatal(err)
+			}
+			info = ciinfo.GetInfoFrom(env, vendors.All)
+			if info.ID != v.Constant || info.IsPR != (v.PR != nil) {
+				t.Errorf("GetInfoFrom(%v) = ID %q, IsPR %v, want %q with IsPR %v", env, info.ID, info.IsPR, v.Constant, v.PR != nil)
+			}
+		})
+	}
+}
+
+func TestEnvUnknown(t *testing.T) {
+	if _, err := Env("NOPE"); err == nil {
+		t.Error("Env of an unknown vendor returned no error")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	env := Simulate(t, "GITHUB_ACTIONS", PR(), Set("GITHUB_REF_NAME", "feature/x"))
+	if got := os.Getenv("GITHUB_REF_NAME"); got != "feature/x" || env["GITHUB_REF_NAME"] != got {
+		t.Errorf("GITHUB_REF_NAME = %q, want the value passed to Set", got)
+	}
+	info := ciinfo.GetInfo()
+	if info.ID != "GITHUB_ACTIONS" || !info.IsPR {
+		t.Fatalf("GetInfo = %+v, want a GitHub Actions pull request", info)
+	}
+	if info.PullRequest.Number != 17 || info.Meta.Commit == "" {
+		t.Errorf("PullRequest = %+v, Meta = %+v, want plausible details", info.PullRequest, info.Meta)
+	}
+
+	Simulate(t, "GITLAB")
+	if info := ciinfo.GetInfo(); info.ID != "GITLAB" || info.IsPR {
+		t.Errorf("GetInfo = %+v, want GitLab without a merge request", info)
+	}
+
+	Clear(t)
+	if info := ciinfo.GetInfo(); info.IsCI {
+		t.Errorf("GetInfo after Clear = %+v, want not CI", info)
+	}
+}
+
+func TestClearRestoresDefault(t *testing.T) {
+	d := &ciinfo.Detector{Env: syntax.Map{"GITLAB_CI": "true"}}
+	ciinfo.SetDefault(d)
+	t.Cleanup(func() { ciinfo.SetDefault(nil) })
+
+	t.Run("clear", func(t *testing.T) {
+		Clear(t)
+		if ciinfo.Default() != nil {
+			t.Error("Clear kept the caller's detector")
+		}
+	})
+	if ciinfo.Default() != d {
+		t.Fatal("Clear did not restore the caller's detector")
+	}
+	if info := ciinfo.GetInfo(); info.ID != "GITLAB" {
+		t.Errorf("GetInfo = %+v, want the caller's detector to report GitLab", info)
+	}
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/startracex/ciinfo"
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, ciinfo.GetInfo))
+}
+
+const usage = `Usage: ciinfo [--format json|text|env] [--strict] [--redact] [--vendors file] [command]
+
+Commands:
+  (none)    print the detected CI information
+  is-ci     exit 0 when running in CI, 1 otherwise
+  is-pr     exit 0 when running for a pull request, 1 otherwise
+  explain   show which rules and variables decided the detection
+  vendors   list the known vendors
+  lint      report vendors that can be detected together; exit 1 on errors
+`
+
+var errUnknownFormat = errors.New("unknown format")
+
+func run(args []string, stdout, stderr io.Writer, getInfo func() ciinfo.Info) int {
+	return runEnv(args, stdout, stderr, getInfo, syntax.LookupFunc(os.LookupEnv))
+}
+
+// runEnv is run reading variables from env. getInfo still reports the
+// detection unless --strict or --vendors change how it is done.
+func runEnv(args []string, stdout, stderr io.Writer, getInfo func() ciinfo.Info, env syntax.Environment) int {
+	fs := flag.NewFlagSet("ciinfo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	format := fs.String("format", "text", "output format: json, text or env")
+	redact := fs.Bool("redact", false, "mask variable values in explain output")
+	strict := fs.Bool("strict", false, "require corroborating variables before reporting CI")
+	vendorsFile := fs.String("vendors", "", "load vendors from a ci-info vendors.json file instead of the built-in ones")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	command := fs.Arg(0)
+	if fs.NArg() > 0 {
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return 2
+		}
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "ciinfo: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+
+	vs := vendors.All
+	if *vendorsFile != "" {
+		var err error
+		if vs, err = vendors.LoadFileStrict(*vendorsFile); err != nil {
+			fmt.Fprintf(stderr, "ciinfo: %v\n", err)
+			return 1
+		}
+	}
+	d := &ciinfo.Detector{Env: env, Vendors: vs, Strict: *strict}
+	if *strict || *vendorsFile != "" {
+		getInfo = d.Detect
+	}
+
+	var err error
+	switch command {
+	case "":
+		err = printInfo(stdout, *format, getInfo())
+	case "is-ci":
+		return exitStatus(getInfo().IsCI)
+	case "is-pr":
+		return exitStatus(getInfo().IsPR)
+	case "explain":
+		ex := d.Explain()
+		if *redact {
+			ex = ex.Redacted()
+		}
+		err = printExplanation(stdout, *format, ex)
+	case "vendors":
+		err = printVendors(stdout, *format, vs)
+	case "lint":
+		findings := vendors.Analyze(vs)
+		if err = printFindings(stdout, *format, findings); err == nil && vendors.Errors(findings) {
+			return 1
+		}
+	default:
+		fmt.Fprintf(stderr, "ciinfo: unknown command %q\n", command)
+		fs.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "ciinfo: %v\n", err)
+		if errors.Is(err, errUnknownFormat) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+func exitStatus(ok bool) int {
+	if ok {
+		return 0
+	}
+	return 1
+}
+
+type field struct {
+	key   string
+	label string
+	value any
+}
+
+func infoFields(info ciinfo.Info) []field {
+	return []field{
+		{"isCI", "IsCI", info.IsCI},
+		{"isPR", "IsPR", info.IsPR},
+		{"id", "ID", info.ID},
+		{"name", "Name", info.Name},
+		{"chain", "Chain", info.Chain},
+		{"confidence", "Confidence", info.Confidence},
+		{"event", "Event", string(info.Event)},
+		{"commit", "Commit", info.Meta.Commit},
+		{"branch", "Branch", info.Meta.Branch},
+		{"tag", "Tag", info.Meta.Tag},
+		{"buildNumber", "BuildNumber", info.Meta.BuildNumber},
+		{"buildID", "BuildID", info.Meta.BuildID},
+		{"jobID", "JobID", info.Meta.JobID},
+		{"buildURL", "BuildURL", info.Meta.BuildURL},
+		{"jobURL", "JobURL", info.Meta.JobURL},
+		{"repo", "Repo", info.Meta.Repo},
+		{"prNumber", "PRNumber", info.PullRequest.Number},
+		{"prSourceBranch", "PRSourceBranch", info.PullRequest.SourceBranch},
+		{"prTargetBranch", "PRTargetBranch", info.PullRequest.TargetBranch},
+		{"prHeadSHA", "PRHeadSHA", info.PullRequest.HeadSHA},
+		{"prBaseSHA", "PRBaseSHA", info.PullRequest.BaseSHA},
+	}
+}
+
+func printInfo(w io.Writer, format string, info ciinfo.Info) error {
+	fields := infoFields(info)
+
+	switch format {
+	case "json":
+		out := make(map[string]any, len(fields))
+		for _, f := range fields {
+			out[f.key] = f.value
+		}
+		return writeJSON(w, out)
+
+	case "text":
+		for _, f := range fields {
+			if isZero(f.value) {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s: %s\n", f.label, formatValue(f.value, " > ")); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "env":
+		for _, f := range fields {
+			if _, err := fmt.Fprintf(w, "CIINFO_%s=%s\n", envName(f.key), shellQuote(formatValue(f.value, ","))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w %q", errUnknownFormat, format)
+}
+
+func printExplanation(w io.Writer, format string, ex ciinfo.Explanation) error {
+	switch format {
+	case "json":
+		return writeJSON(w, ex)
+	case "text":
+		_, err := io.WriteString(w, ex.String())
+		return err
+	}
+
+	return fmt.Errorf("%w %q", errUnknownFormat, format)
+}
+
+func printVendors(w io.Writer, format string, vs []vendors.Vendor) error {
+	switch format {
+	case "json":
+		out := make([]map[string]string, len(vs))
+		for i, v := range vs {
+			out[i] = map[string]string{"constant": v.Constant, "name": v.Name}
+		}
+		return writeJSON(w, out)
+
+	case "text", "env":
+		for _, v := range vs {
+			if _, err := fmt.Fprintf(w, "%s=%s\n", v.Constant, shellQuote(v.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w %q", errUnknownFormat, format)
+}
+
+func printFindings(w io.Writer, format string, findings []vendors.Finding) error {
+	switch format {
+	case "json":
+		if findings == nil {
+			findings = []vendors.Finding{}
+		}
+		return writeJSON(w, findings)
+
+	case "text":
+		for _, f := range findings {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", f.Severity, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w %q", errUnknownFormat, format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func isZero(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+func formatValue(v any, sep string) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, sep)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// envName converts a camelCase key to UPPER_SNAKE_CASE.
+func envName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if i > 0 && r >= 'A' && r <= 'Z' && !(key[i-1] >= 'A' && key[i-1] <= 'Z') {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_-./:@", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/startracex/ciinfo"
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func fixedInfo(info ciinfo.Info) func() ciinfo.Info {
+	return func() ciinfo.Info { return info }
+}
+
+func TestRunExitStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		info ciinfo.Info
+		want int
+	}{
+		{"is-ci true", []string{"is-ci"}, ciinfo.Info{IsCI: true}, 0},
+		{"is-ci false", []string{"is-ci"}, ciinfo.Info{}, 1},
+		{"is-pr true", []string{"is-pr"}, ciinfo.Info{IsCI: true, IsPR: true}, 0},
+		{"is-pr false", []string{"is-pr"}, ciinfo.Info{IsCI: true}, 1},
+		{"unknown command", []string{"nope"}, ciinfo.Info{}, 2},
+		{"unknown format", []string{"--format", "xml"}, ciinfo.Info{}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.args, &stdout, &stderr, fixedInfo(tt.info)); got != tt.want {
+				t.Errorf("run(%q) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFormats(t *testing.T) {
+	info := ciinfo.Info{
+		IsCI: true,
+		ID:   "GITHUB_ACTIONS",
+		Name: "GitHub Actions",
+		Meta: ciinfo.Meta{Commit: "abc"},
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--format=json"}, &stdout, &stderr, fixedInfo(info)); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	var out map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if out["isCI"] != true || out["id"] != "GITHUB_ACTIONS" || out["commit"] != "abc" {
+		t.Errorf("unexpected json output: %v", out)
+	}
+
+	stdout.Reset()
+	run([]string{"--format", "env"}, &stdout, &stderr, fixedInfo(info))
+	for _, line := range []string{"CIINFO_IS_CI=true", "CIINFO_NAME='GitHub Actions'", "CIINFO_BUILD_URL=''"} {
+		if !strings.Contains(stdout.String(), line+"\n") {
+			t.Errorf("env output missing %q:\n%s", line, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	run(nil, &stdout, &stderr, fixedInfo(info))
+	if got := stdout.String(); !strings.Contains(got, "Name: GitHub Actions\n") || strings.Contains(got, "Branch:") {
+		t.Errorf("unexpected text output:\n%s", got)
+	}
+}
+
+func TestRunVendors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"vendors", "--format", "text"}, &stdout, &stderr, fixedInfo(ciinfo.Info{})); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "GITHUB_ACTIONS='GitHub Actions'\n") {
+		t.Errorf("vendors output missing GitHub Actions:\n%s", stdout.String())
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	env := map[string]string{"BUILD_ID": "secret-build"}
+
+	var stdout, stderr bytes.Buffer
+	if code := runEnv([]string{"explain", "--redact"}, &stdout, &stderr, fixedInfo(ciinfo.Info{}), syntax.Map(env)); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if got := stdout.String(); !strings.Contains(got, "BUILD_ID") || strings.Contains(got, "secret-build") {
+		t.Errorf("unexpected explain output:\n%s", got)
+	}
+
+	env = map[string]string{
+		"JENKINS_URL": "https://jenkins.internal/",
+		"BUILD_ID":    "secret-build",
+		"BUILD_URL":   "https://jenkins.internal/job/x/42/",
+		"GIT_COMMIT":  "deadbeef",
+		"CHANGE_ID":   "1234",
+	}
+	stdout.Reset()
+	if code := runEnv([]string{"--format", "json", "--redact", "explain"}, &stdout, &stderr, fixedInfo(ciinfo.Info{}), syntax.Map(env)); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	for _, v := range env {
+		if strings.Contains(stdout.String(), v) {
+			t.Errorf("json explain output leaks %q:\n%s", v, stdout.String())
+		}
+	}
+}
+
+func TestRunLint(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lint"}, &stdout, &stderr, fixedInfo(ciinfo.Info{})); code != 0 {
+		t.Fatalf("lint of built-in vendors: exit %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "info: JENKINS and the generic fallback both read BUILD_ID") {
+		t.Errorf("lint of built-in vendors missing the fallback overlap:\n%s", stdout.String())
+	}
+
+	file := filepath.Join(t.TempDir(), "vendors.json")
+	data := `[
+		{"name": "Woodpecker", "constant": "WOODPECKER", "env": {"CI": "woodpecker"}},
+		{"name": "Any CI", "constant": "ANY_CI", "env": "CI"}
+	]`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"--vendors", file, "lint"}, &stdout, &stderr, fixedInfo(ciinfo.Info{})); code != 1 {
+		t.Fatalf("lint: exit %d, want 1: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "WOODPECKER is subsumed by ANY_CI") {
+		t.Errorf("lint output missing subsumption:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	run([]string{"--vendors", file, "--format", "json", "lint"}, &stdout, &stderr, fixedInfo(ciinfo.Info{}))
+	var findings []map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil || len(findings) != 5 || findings[1]["kind"] != "overlap" || findings[1]["severity"] != "error" {
+		t.Errorf("unexpected json output (%v):\n%s", err, stdout.String())
+	}
+
+	if code := run([]string{"--vendors", filepath.Join(t.TempDir(), "missing.json"), "lint"}, &stdout, &stderr, fixedInfo(ciinfo.Info{})); code != 1 {
+		t.Errorf("missing vendors file: exit %d, want 1", code)
+	}
+}
+
+func TestRunStrict(t *testing.T) {
+	env := syntax.Map{"TRAVIS": "true"}
+
+	var stdout, stderr bytes.Buffer
+	if code := runEnv([]string{"--strict", "is-ci"}, &stdout, &stderr, fixedInfo(ciinfo.Info{IsCI: true}), env); code != 1 {
+		t.Errorf("--strict is-ci with an uncorroborated vendor: exit %d, want 1", code)
+	}
+	env["TRAVIS_BUILD_ID"] = "1"
+	env["TRAVIS_JOB_ID"] = "2"
+	if code := runEnv([]string{"--strict", "is-ci"}, &stdout, &stderr, fixedInfo(ciinfo.Info{}), env); code != 0 {
+		t.Errorf("--strict is-ci with a corroborated vendor: exit %d, want 0", code)
+	}
+}
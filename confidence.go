@@ -0,0 +1,52 @@
+package ciinfo
+
+import (
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+// neutralConfidence is the score of a match that can be neither backed up
+// nor contradicted.
+const neutralConfidence = 0.5
+
+// vendorConfidence scores a vendor match between 0 and 1. The matching
+// marker alone is worth neutralConfidence, whether or not the vendor
+// declares Corroborate variables, and the share of those that are set lifts
+// the score towards 1.
+func vendorConfidence(v *vendors.Vendor, env syntax.Environment) float64 {
+	if len(v.Corroborate) == 0 {
+		return neutralConfidence
+	}
+	share := float64(countSet(env, v.Corroborate)) / float64(len(v.Corroborate))
+	return neutralConfidence + (1-neutralConfidence)*share
+}
+
+// corroborated reports whether a Corroborate variable backs up the match. A
+// vendor that declares none has no corroboration available and is accepted
+// on its marker.
+func corroborated(v *vendors.Vendor, env syntax.Environment) bool {
+	return len(v.Corroborate) == 0 || countSet(env, v.Corroborate) > 0
+}
+
+// fallbackConfidence scores detection from vendors.CommonKeys() alone: a single key is
+// weak evidence, several together are as good as a vendor that declares no
+// Corroborate variables.
+func fallbackConfidence(env syntax.Environment) float64 {
+	switch countSet(env, vendors.CommonKeys()) {
+	case 0:
+		return 0
+	case 1:
+		return neutralConfidence / 2
+	}
+	return neutralConfidence
+}
+
+func countSet(env syntax.Environment, keys []string) int {
+	n := 0
+	for _, k := range keys {
+		if v, _ := env.Lookup(k); v != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+package ciinfo
+
+import (
+	"testing"
+
+	"github.com/startracex/ciinfo/vendors"
+)
+
+func TestConfidence(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		want   float64
+		strict bool
+	}{
+		{
+			name: "stray variable",
+			env:  map[string]string{"TRAVIS": "true"},
+			want: neutralConfidence,
+		},
+		{
+			name:   "partly corroborated",
+			env:    map[string]string{"TRAVIS": "true", "TRAVIS_BUILD_ID": "1", "TRAVIS_JOB_ID": "2"},
+			want:   0.75,
+			strict: true,
+		},
+		{
+			name: "fully corroborated",
+			env: map[string]string{
+				"TRAVIS":           "true",
+				"TRAVIS_BUILD_ID":  "1",
+				"TRAVIS_JOB_ID":    "2",
+				"TRAVIS_REPO_SLUG": "o/r",
+				"TRAVIS_COMMIT":    "abc",
+			},
+			want:   1,
+			strict: true,
+		},
+		{
+			name:   "nothing to corroborate",
+			env:    map[string]string{"DSARI": "1"},
+			want:   neutralConfidence,
+			strict: true,
+		},
+		{
+			name: "single common key",
+			env:  map[string]string{"BUILD_ID": "1"},
+			want: neutralConfidence / 2,
+		},
+		{
+			name:   "several common keys",
+			env:    map[string]string{"BUILD_ID": "1", "CI": "true"},
+			want:   neutralConfidence,
+			strict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := GetInfoFrom(tt.env, vendors.All)
+			if info.Confidence != tt.want {
+				t.Errorf("Confidence = %v, want %v", info.Confidence, tt.want)
+			}
+			if strict := GetInfoFromStrict(tt.env, vendors.All); strict.IsCI != tt.strict {
+				t.Errorf("strict IsCI = %v, want %v", strict.IsCI, tt.strict)
+			}
+		})
+	}
+}
+
+func TestGetInfoFromStrict(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		wantCI bool
+		wantID string
+	}{
+		{
+			name:   "stray vendor variable",
+			env:    map[string]string{"DRONE": "true"},
+			wantCI: false,
+		},
+		{
+			name:   "corroborated vendor",
+			env:    map[string]string{"DRONE": "true", "DRONE_BUILD_NUMBER": "4"},
+			wantCI: true,
+			wantID: "DRONE",
+		},
+		{
+			name:   "vendor without corroboration data",
+			env:    map[string]string{"DSARI": "1"},
+			wantCI: true,
+			wantID: "DSARI",
+		},
+		{
+			name:   "single common key",
+			env:    map[string]string{"BUILD_ID": "1"},
+			wantCI: false,
+		},
+		{
+			name:   "several common keys",
+			env:    map[string]string{"BUILD_ID": "1", "CI": "true"},
+			wantCI: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := GetInfoFromStrict(tt.env, vendors.All)
+			if info.IsCI != tt.wantCI || info.ID != tt.wantID {
+				t.Errorf("IsCI = %v, ID = %q, want %v, %q", info.IsCI, info.ID, tt.wantCI, tt.wantID)
+			}
+			if lenient := GetInfoFrom(tt.env, vendors.All); !lenient.IsCI {
+				t.Error("lenient detection should report CI")
+			}
+		})
+	}
+}
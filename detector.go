@@ -0,0 +1,103 @@
+package ciinfo
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+// Detector detects CI information from one environment. The zero value reads
+// the process environment and uses vendors.All.
+type Detector struct {
+	Env     syntax.Environment
+	Vendors []vendors.Vendor
+	// Strict has the same effect as GetInfoFromStrict.
+	Strict bool
+}
+
+func (d *Detector) env() syntax.Environment {
+	if d.Env == nil {
+		return syntax.LookupFunc(os.LookupEnv)
+	}
+	return d.Env
+}
+
+func (d *Detector) vendors() []vendors.Vendor {
+	if d.Vendors == nil {
+		return vendors.All
+	}
+	return d.Vendors
+}
+
+func (d *Detector) Detect() Info {
+	return detect(d.env(), d.vendors(), d.Strict)
+}
+
+func (d *Detector) Explain() Explanation {
+	return explain(d.env(), d.vendors(), d.Strict)
+}
+
+var defaults struct {
+	mu       sync.Mutex
+	detector *Detector
+	info     *Info
+}
+
+// GetInfo returns the result of the default detector, which reads the
+// process environment. The result is computed once and cached until Reset
+// or SetDefault.
+func GetInfo() Info {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+
+	if defaults.info == nil {
+		d := defaults.detector
+		if d == nil {
+			d = &Detector{}
+		}
+		info := d.Detect()
+		defaults.info = &info
+	}
+	return *defaults.info
+}
+
+// Reset drops the result cached by GetInfo, so the next call detects again.
+// Tests that change the environment with t.Setenv should call it.
+func Reset() {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+	defaults.info = nil
+}
+
+// Default returns the detector GetInfo uses, or nil for the zero Detector.
+func Default() *Detector {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+	return defaults.detector
+}
+
+// SetDefault replaces the detector GetInfo uses and drops its cached result.
+// A nil d restores the zero Detector.
+func SetDefault(d *Detector) {
+	defaults.mu.Lock()
+	defer defaults.mu.Unlock()
+	defaults.detector = d
+	defaults.info = nil
+}
+
+type contextKey struct{}
+
+// WithInfo returns a copy of ctx carrying info, for code that evaluates more
+// than one job per process.
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the Info stored by WithInfo.
+func FromContext(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(contextKey{}).(Info)
+	return info, ok
+}
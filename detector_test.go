@@ -0,0 +1,76 @@
+package ciinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+func TestDetector(t *testing.T) {
+	d := &Detector{Env: syntax.Map{"TRAVIS": "true"}}
+	if info := d.Detect(); !info.IsCI || info.ID != "TRAVIS" {
+		t.Errorf("Detect = %+v, want Travis CI", info)
+	}
+
+	d.Strict = true
+	if info := d.Detect(); info.ID == "TRAVIS" {
+		t.Errorf("strict Detect = %+v, want no uncorroborated vendor", info)
+	}
+	ex := d.Explain()
+	if ex.Info.ID == "TRAVIS" {
+		t.Errorf("strict Explain = %+v, want no uncorroborated vendor", ex.Info)
+	}
+	for _, vt := range ex.Vendors {
+		if vt.Constant == "TRAVIS" && (!vt.Matched || !vt.Rejected || vt.Reason == "") {
+			t.Errorf("strict Explain trace = %+v, want Travis CI matched but rejected", vt)
+		}
+	}
+	if !strings.Contains(ex.String(), "rejected by strict detection") {
+		t.Errorf("String() should say why Travis CI was rejected:\n%s", ex)
+	}
+
+	d = &Detector{Env: syntax.Map{"TRAVIS": "true"}, Vendors: []vendors.Vendor{}}
+	if info := d.Detect(); info.ID != "" {
+		t.Errorf("Detect with no vendors = %+v, want no vendor", info)
+	}
+}
+
+func TestSetDefaultAndReset(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	env := syntax.Map{"GITHUB_ACTIONS": "true"}
+	d := &Detector{Env: env}
+	SetDefault(d)
+	if Default() != d {
+		t.Error("Default does not return the detector passed to SetDefault")
+	}
+	if info := GetInfo(); info.ID != "GITHUB_ACTIONS" {
+		t.Fatalf("GetInfo = %+v, want GitHub Actions", info)
+	}
+
+	delete(env, "GITHUB_ACTIONS")
+	env["GITLAB_CI"] = "true"
+	if info := GetInfo(); info.ID != "GITHUB_ACTIONS" {
+		t.Errorf("GetInfo before Reset = %+v, want cached GitHub Actions", info)
+	}
+	Reset()
+	if info := GetInfo(); info.ID != "GITLAB" {
+		t.Errorf("GetInfo after Reset = %+v, want GitLab", info)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := FromContext(ctx); ok {
+		t.Error("FromContext on empty context reported ok")
+	}
+
+	want := Info{IsCI: true, ID: "GITLAB", Name: "GitLab CI"}
+	got, ok := FromContext(WithInfo(ctx, want))
+	if !ok || got.ID != want.ID || got.Name != want.Name || !got.IsCI {
+		t.Errorf("FromContext = %+v, %v, want %+v", got, ok, want)
+	}
+}
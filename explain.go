@@ -0,0 +1,234 @@
+package ciinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+type Explanation struct {
+	Info     Info
+	Disabled bool
+	Vendors  []VendorTrace
+	Fallback *Var
+}
+
+type VendorTrace struct {
+	Constant      string
+	Name          string
+	Matched       bool
+	Rejected      bool   // matched, but strict detection did not accept it
+	Reason        string // why it was rejected
+	Env           []EnvTrace
+	PR            *PRTrace
+	Confidence    float64
+	Corroboration []Var
+}
+
+type EnvTrace struct {
+	Rule    syntax.Env
+	Vars    []Var
+	Matched bool
+}
+
+type PRTrace struct {
+	Rule    syntax.PR
+	Vars    []Var
+	Matched bool
+}
+
+type Var struct {
+	Key   string
+	Value string
+	Set   bool
+}
+
+// Explain evaluates every vendor rule against env the same way GetInfoFrom
+// does and records what each rule read and decided.
+func Explain(env map[string]string, vendors []vendors.Vendor) Explanation {
+	return explain(syntax.Map(env), vendors, false)
+}
+
+func explain(env syntax.Environment, vendors []vendors.Vendor, strict bool) Explanation {
+	ci, _ := env.Lookup("CI")
+	ex := Explanation{
+		Info:     detect(env, vendors, strict),
+		Disabled: isExplicitlyFalseLike(ci),
+	}
+	if ex.Disabled {
+		return ex
+	}
+
+	ex.Vendors = make([]VendorTrace, len(vendors))
+	for i, vendor := range vendors {
+		vt := VendorTrace{
+			Constant: vendor.Constant,
+			Name:     vendor.Name,
+			Matched:  true,
+			Env:      make([]EnvTrace, len(vendor.Env)),
+		}
+
+		for j, rule := range vendor.Env {
+			vt.Env[j] = EnvTrace{
+				Rule:    rule,
+				Vars:    lookupVars(env, rule.Keys()),
+				Matched: rule.Match(env),
+			}
+			vt.Matched = vt.Matched && vt.Env[j].Matched
+		}
+
+		if vt.Matched {
+			vt.Confidence = vendorConfidence(&vendor, env)
+			vt.Corroboration = lookupVars(env, vendor.Corroborate)
+			if strict && !corroborated(&vendor, env) {
+				vt.Rejected = true
+				vt.Reason = "no corroborating variable is set"
+			}
+		}
+
+		if vt.Matched && vendor.PR != nil {
+			vt.PR = &PRTrace{
+				Rule:    *vendor.PR,
+				Vars:    lookupVars(env, vendor.PR.Keys()),
+				Matched: vendor.PR.Match(env),
+			}
+		}
+
+		ex.Vendors[i] = vt
+	}
+
+	if len(ex.Info.Vendors) == 0 {
+		if k := commonKey(env); k != "" {
+			v, _ := env.Lookup(k)
+			ex.Fallback = &Var{Key: k, Value: v, Set: true}
+		}
+	}
+
+	return ex
+}
+
+func lookupVars(env syntax.Environment, keys []string) []Var {
+	vars := make([]Var, len(keys))
+	for i, k := range keys {
+		v, ok := env.Lookup(k)
+		vars[i] = Var{Key: k, Value: v, Set: ok}
+	}
+	return vars
+}
+
+// Redacted returns a copy of ex with every non-empty variable value masked.
+func (ex Explanation) Redacted() Explanation {
+	redact := func(vars []Var) []Var {
+		out := make([]Var, len(vars))
+		for i, v := range vars {
+			if v.Value != "" {
+				v.Value = "***"
+			}
+			out[i] = v
+		}
+		return out
+	}
+
+	vts := make([]VendorTrace, len(ex.Vendors))
+	for i, vt := range ex.Vendors {
+		envs := make([]EnvTrace, len(vt.Env))
+		for j, et := range vt.Env {
+			et.Vars = redact(et.Vars)
+			envs[j] = et
+		}
+		vt.Env = envs
+		vt.Corroboration = redact(vt.Corroboration)
+		if vt.PR != nil {
+			pr := *vt.PR
+			pr.Vars = redact(pr.Vars)
+			vt.PR = &pr
+		}
+		vts[i] = vt
+	}
+	ex.Vendors = vts
+
+	if ex.Fallback != nil {
+		ex.Fallback = &redact([]Var{*ex.Fallback})[0]
+	}
+
+	// Meta and PullRequest are read from the same variables.
+	mask := func(fields ...*string) {
+		for _, f := range fields {
+			if *f != "" {
+				*f = "***"
+			}
+		}
+	}
+	m := &ex.Info.Meta
+	mask(&m.Commit, &m.Branch, &m.Tag, &m.BuildNumber, &m.BuildID, &m.JobID, &m.BuildURL, &m.JobURL, &m.Repo)
+	pr := &ex.Info.PullRequest
+	mask(&pr.SourceBranch, &pr.TargetBranch, &pr.HeadSHA, &pr.BaseSHA)
+	pr.Number = 0
+	return ex
+}
+
+func (ex Explanation) String() string {
+	var b strings.Builder
+
+	if ex.Disabled {
+		b.WriteString("CI is explicitly disabled by CI=false or CI=0\n")
+		return b.String()
+	}
+
+	for _, vt := range ex.Vendors {
+		if !vt.Matched && !anySet(vt.Env) {
+			continue
+		}
+		fmt.Fprintf(&b, "%s (%s): matched=%t\n", vt.Constant, vt.Name, vt.Matched)
+		for _, et := range vt.Env {
+			fmt.Fprintf(&b, "  env %s: %s => %t\n", et.Rule, formatVars(et.Vars), et.Matched)
+		}
+		switch {
+		case vt.Matched && len(vt.Corroboration) > 0:
+			fmt.Fprintf(&b, "  confidence %.2f from %s\n", vt.Confidence, formatVars(vt.Corroboration))
+		case vt.Matched:
+			fmt.Fprintf(&b, "  confidence %.2f, nothing to corroborate\n", vt.Confidence)
+		}
+		if vt.Rejected {
+			fmt.Fprintf(&b, "  rejected by strict detection: %s\n", vt.Reason)
+		}
+		if vt.PR != nil {
+			fmt.Fprintf(&b, "  pr  %s: %s => %t\n", vt.PR.Rule, formatVars(vt.PR.Vars), vt.PR.Matched)
+		}
+	}
+
+	switch {
+	case ex.Info.ID != "":
+		fmt.Fprintf(&b, "detected %s (%s)\n", ex.Info.ID, ex.Info.Name)
+	case ex.Fallback != nil:
+		fmt.Fprintf(&b, "no vendor matched; CI detected from common key %s=%q (confidence %.2f)\n", ex.Fallback.Key, ex.Fallback.Value, ex.Info.Confidence)
+	default:
+		b.WriteString("no vendor matched and no common key is set\n")
+	}
+	return b.String()
+}
+
+func anySet(ets []EnvTrace) bool {
+	for _, et := range ets {
+		for _, v := range et.Vars {
+			if v.Set {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatVars(vars []Var) string {
+	parts := make([]string, len(vars))
+	for i, v := range vars {
+		if v.Set {
+			parts[i] = fmt.Sprintf("%s=%q", v.Key, v.Value)
+		} else {
+			parts[i] = v.Key + " unset"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
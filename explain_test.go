@@ -0,0 +1,97 @@
+package ciinfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+	"github.com/startracex/ciinfo/vendors"
+)
+
+func TestExplain_VendorMatch(t *testing.T) {
+	vlist := []vendors.Vendor{
+		{
+			Name:     "TestCI",
+			Constant: "TEST",
+			Env: syntax.EnvList{
+				{StrictEqual: "TEST_ENV"},
+			},
+			PR: &syntax.PR{StrictEqual: "TEST_PR", NotEqual: "false"},
+		},
+	}
+
+	env := map[string]string{
+		"TEST_ENV": "1",
+		"TEST_PR":  "false",
+	}
+
+	ex := Explain(env, vlist)
+	if len(ex.Vendors) != 1 {
+		t.Fatalf("len(Vendors) = %d, want 1", len(ex.Vendors))
+	}
+	vt := ex.Vendors[0]
+	if !vt.Matched || !vt.Env[0].Matched {
+		t.Error("vendor should match")
+	}
+	if vt.Env[0].Vars[0] != (Var{Key: "TEST_ENV", Value: "1", Set: true}) {
+		t.Errorf("Env vars = %+v", vt.Env[0].Vars)
+	}
+	if vt.PR == nil || vt.PR.Matched {
+		t.Errorf("PR trace = %+v, want evaluated and not matched", vt.PR)
+	}
+	if ex.Fallback != nil {
+		t.Errorf("Fallback = %+v, want nil", ex.Fallback)
+	}
+	if ex.Info.ID != "TEST" {
+		t.Errorf("Info.ID = %q, want TEST", ex.Info.ID)
+	}
+}
+
+func TestExplain_Fallback(t *testing.T) {
+	env := map[string]string{
+		"BUILD_ID": "123",
+	}
+
+	ex := Explain(env, vendors.All)
+	if ex.Fallback == nil || ex.Fallback.Key != "BUILD_ID" {
+		t.Fatalf("Fallback = %+v, want BUILD_ID", ex.Fallback)
+	}
+	for _, vt := range ex.Vendors {
+		if vt.Matched {
+			t.Errorf("vendor %s should not match", vt.Constant)
+		}
+	}
+	if !strings.Contains(ex.String(), "common key BUILD_ID") {
+		t.Errorf("String() should mention the fallback key:\n%s", ex)
+	}
+}
+
+func TestExplain_Disabled(t *testing.T) {
+	ex := Explain(map[string]string{"CI": "false", "TRAVIS": "true"}, vendors.All)
+	if !ex.Disabled || len(ex.Vendors) != 0 {
+		t.Errorf("Explain = %+v, want disabled with no traces", ex)
+	}
+}
+
+func TestExplanation_Redacted(t *testing.T) {
+	env := map[string]string{
+		"JENKINS_URL": "https://jenkins.internal",
+		"BUILD_ID":    "42",
+		"BUILD_URL":   "https://jenkins.internal/job/x/42/",
+	}
+
+	ex := Explain(env, []vendors.Vendor{vendors.VendorJENKINS}).Redacted()
+	if ex.Info.Meta.BuildURL != "***" || ex.Info.Meta.BuildID != "***" {
+		t.Errorf("Info.Meta = %+v, want redacted", ex.Info.Meta)
+	}
+	for _, et := range ex.Vendors[0].Env {
+		for _, v := range et.Vars {
+			if v.Value != "***" {
+				t.Errorf("%s = %q, want redacted", v.Key, v.Value)
+			}
+		}
+	}
+	if strings.Contains(ex.String(), "jenkins.internal") {
+		t.Errorf("String() leaks a redacted value:\n%s", ex)
+	}
+}
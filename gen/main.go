@@ -9,15 +9,22 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/startracex/ciinfo/syntax"
 	"github.com/startracex/ciinfo/vendors"
 )
 
 func main() {
 	root := ".."
-	data, err := os.ReadFile(filepath.Join(root, "node_modules/ci-info/vendors.json"))
+	embedded := filepath.Join(root, "vendors/vendors.json")
 
-	if err != nil {
-		panic("ci-info npm package is not installed")
+	// An installed ci-info package refreshes the embedded copy; otherwise the
+	// embedded copy is used as is.
+	data, err := os.ReadFile(filepath.Join(root, "node_modules/ci-info/vendors.json"))
+	if err == nil {
+		mayPanic(os.WriteFile(embedded, data, 0644))
+	} else {
+		data, err = os.ReadFile(embedded)
+		mayPanic(err)
 	}
 
 	var vs []vendors.Vendor
@@ -35,28 +42,23 @@ import (
 
 	buf.WriteString("var (\n")
 	for _, rv := range vs {
-		fmt.Fprintf(&buf, "Vendor%s = Vendor{", rv.Constant)
+		fmt.Fprintf(&buf, "Vendor%s = extend(Vendor{", rv.Constant)
 
 		fmt.Fprintf(&buf, "Name:%q, Constant:%q,", rv.Name, rv.Constant)
 
 		buf.WriteString("Env: syntax.EnvList{")
 		for _, e := range rv.Env {
-			fmt.Fprintf(&buf,
-				"{StrictEqual:%q, Includes:%q, EqualsAnyOf:%#v, EqualsMap:%#v},",
-				e.StrictEqual, e.Includes, e.EqualsAnyOf, e.EqualsMap)
+			buf.WriteString(envLiteral(e) + ",")
 		}
 		buf.WriteString("},")
 
 		if rv.PR != nil {
-			pr := rv.PR
-			fmt.Fprintf(&buf,
-				"PR: &syntax.PR{StrictEqual:%q, NotEqual:%q, EqualsAnyOf:%#v, EqualsMap:%#v},",
-				pr.StrictEqual, pr.NotEqual, pr.EqualsAnyOf, pr.EqualsMap)
+			fmt.Fprintf(&buf, "PR: &syntax.PR%s,", prLiteral(*rv.PR))
 		} else {
 			buf.WriteString("PR:nil,")
 		}
 
-		buf.WriteString("}\n")
+		buf.WriteString("})\n")
 	}
 
 	buf.WriteString("All = []Vendor{")
@@ -71,6 +73,67 @@ import (
 	mayPanic(err)
 
 	mayPanic(os.WriteFile(filepath.Join(root, "/vendors/vendors_gen.go"), out, 0644))
+
+	// Report vendors whose rules can be detected together, with the
+	// hand-written nesting from extras applied.
+	loaded, err := vendors.Load(bytes.NewReader(data))
+	mayPanic(err)
+	for _, f := range vendors.Analyze(loaded) {
+		fmt.Fprintln(os.Stderr, "gen:", f)
+	}
+}
+
+func envLiteral(e syntax.Env) string {
+	s := fmt.Sprintf("{StrictEqual:%q, Includes:%q, EqualsAnyOf:%#v, EqualsMap:%#v%s",
+		e.StrictEqual, e.Includes, e.EqualsAnyOf, e.EqualsMap, predicates(e.Predicates))
+	if len(e.All) > 0 {
+		s += ", All: []syntax.Env{"
+		for _, c := range e.All {
+			s += envLiteral(c) + ","
+		}
+		s += "}"
+	}
+	if len(e.Any) > 0 {
+		s += ", Any: []syntax.Env{"
+		for _, c := range e.Any {
+			s += envLiteral(c) + ","
+		}
+		s += "}"
+	}
+	if e.Not != nil {
+		s += ", Not: &syntax.Env" + envLiteral(*e.Not)
+	}
+	return s + "}"
+}
+
+func prLiteral(pr syntax.PR) string {
+	s := fmt.Sprintf("{StrictEqual:%q, NotEqual:%q, EqualsAnyOf:%#v, EqualsMap:%#v%s",
+		pr.StrictEqual, pr.NotEqual, pr.EqualsAnyOf, pr.EqualsMap, predicates(pr.Predicates))
+	if len(pr.All) > 0 {
+		s += ", All: []syntax.PR{"
+		for _, c := range pr.All {
+			s += prLiteral(c) + ","
+		}
+		s += "}"
+	}
+	if len(pr.Any) > 0 {
+		s += ", Any: []syntax.PR{"
+		for _, c := range pr.Any {
+			s += prLiteral(c) + ","
+		}
+		s += "}"
+	}
+	if pr.Not != nil {
+		s += ", Not: &syntax.PR" + prLiteral(*pr.Not)
+	}
+	return s + "}"
+}
+
+func predicates(p syntax.Predicates) string {
+	if p == (syntax.Predicates{}) {
+		return ""
+	}
+	return fmt.Sprintf(", Predicates:%#v", p)
 }
 
 func mayPanic(err error) {
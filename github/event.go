@@ -0,0 +1,159 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+var ErrNoEventPath = errors.New("GITHUB_EVENT_PATH is not set")
+
+// Event is the webhook payload GitHub Actions writes to GITHUB_EVENT_PATH,
+// reduced to the fields that describe the run.
+type Event struct {
+	Name        string         `json:"-"`
+	Action      string         `json:"action"`
+	Number      int            `json:"number"`
+	PullRequest *PullRequest   `json:"pull_request"`
+	MergeGroup  *MergeGroup    `json:"merge_group"`
+	Repository  *Repository    `json:"repository"`
+	Sender      *User          `json:"sender"`
+	Inputs      map[string]any `json:"inputs"`
+	Ref         string         `json:"ref"`
+	Before      string         `json:"before"`
+	After       string         `json:"after"`
+}
+
+type PullRequest struct {
+	Number  int     `json:"number"`
+	Title   string  `json:"title"`
+	Body    string  `json:"body"`
+	State   string  `json:"state"`
+	Draft   bool    `json:"draft"`
+	Merged  bool    `json:"merged"`
+	HTMLURL string  `json:"html_url"`
+	User    User    `json:"user"`
+	Labels  []Label `json:"labels"`
+	Head    Branch  `json:"head"`
+	Base    Branch  `json:"base"`
+}
+
+type Branch struct {
+	Label string      `json:"label"`
+	Ref   string      `json:"ref"`
+	SHA   string      `json:"sha"`
+	Repo  *Repository `json:"repo"`
+}
+
+type MergeGroup struct {
+	HeadSHA string `json:"head_sha"`
+	HeadRef string `json:"head_ref"`
+	BaseSHA string `json:"base_sha"`
+	BaseRef string `json:"base_ref"`
+}
+
+type Repository struct {
+	FullName      string `json:"full_name"`
+	Fork          bool   `json:"fork"`
+	Private       bool   `json:"private"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+	Owner         User   `json:"owner"`
+}
+
+type User struct {
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+// ReadEvent reads the payload named by env["GITHUB_EVENT_PATH"] from fsys.
+// The leading slash of an absolute path is dropped, so os.DirFS("/") and
+// test fixtures rooted at the event file's directory both work.
+func ReadEvent(fsys fs.FS, env map[string]string) (*Event, error) {
+	path := env["GITHUB_EVENT_PATH"]
+	if path == "" {
+		return nil, ErrNoEventPath
+	}
+	data, err := fs.ReadFile(fsys, strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return parseEvent(env["GITHUB_EVENT_NAME"], data)
+}
+
+// LoadEvent reads the payload named by env["GITHUB_EVENT_PATH"] from the
+// local file system.
+func LoadEvent(env map[string]string) (*Event, error) {
+	path := env["GITHUB_EVENT_PATH"]
+	if path == "" {
+		return nil, ErrNoEventPath
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseEvent(env["GITHUB_EVENT_NAME"], data)
+}
+
+func parseEvent(name string, data []byte) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	e.Name = name
+	return &e, nil
+}
+
+// IsPullRequest reports whether the run was triggered by a pull request,
+// including pull_request_target runs that execute in the base repository.
+func (e *Event) IsPullRequest() bool {
+	return (e.Name == "pull_request" || e.Name == "pull_request_target") && e.PullRequest != nil
+}
+
+func (e *Event) IsMergeGroup() bool {
+	return e.Name == "merge_group" && e.MergeGroup != nil
+}
+
+// IsFork reports whether the pull request comes from a different repository
+// than the one it targets.
+func (pr *PullRequest) IsFork() bool {
+	if pr.Head.Repo == nil || pr.Base.Repo == nil {
+		return pr.Head.Repo == nil && pr.Base.Repo != nil
+	}
+	return pr.Head.Repo.FullName != pr.Base.Repo.FullName
+}
+
+func (pr *PullRequest) HasLabel(name string) bool {
+	for _, l := range pr.Labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Input returns a workflow_dispatch input as a string. Boolean and number
+// inputs are formatted the way they appear in the workflow file.
+func (e *Event) Input(name string) (string, bool) {
+	v, ok := e.Inputs[name]
+	if !ok {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", true
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+var fixtures = os.DirFS("testdata")
+
+func TestReadEvent_PullRequest(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_EVENT_NAME": "pull_request_target",
+		"GITHUB_EVENT_PATH": "/pull_request.json",
+	}
+
+	e, err := ReadEvent(fixtures, env)
+	if err != nil {
+		t.Fatalf("ReadEvent failed: %v", err)
+	}
+	if !e.IsPullRequest() {
+		t.Fatal("pull_request_target should be a pull request")
+	}
+
+	pr := e.PullRequest
+	if pr.Number != 42 || pr.Title != "Add widget" || !pr.Draft {
+		t.Errorf("PullRequest = %+v", pr)
+	}
+	if pr.Head.Ref != "widget" || pr.Base.Ref != "main" || pr.Base.SHA != "9049f1265b7d61be4a8904a9a27120d2064dab3b" {
+		t.Errorf("Head = %+v, Base = %+v", pr.Head, pr.Base)
+	}
+	if !pr.IsFork() {
+		t.Error("IsFork should be true")
+	}
+	if !pr.HasLabel("ci:full") || pr.HasLabel("bug") {
+		t.Errorf("Labels = %+v", pr.Labels)
+	}
+	if e.Sender.Login != "contributor" {
+		t.Errorf("Sender = %+v", e.Sender)
+	}
+}
+
+func TestReadEvent_WorkflowDispatch(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_EVENT_NAME": "workflow_dispatch",
+		"GITHUB_EVENT_PATH": "workflow_dispatch.json",
+	}
+
+	e, err := ReadEvent(fixtures, env)
+	if err != nil {
+		t.Fatalf("ReadEvent failed: %v", err)
+	}
+	if e.IsPullRequest() {
+		t.Error("workflow_dispatch is not a pull request")
+	}
+
+	inputs := map[string]string{
+		"environment": "staging",
+		"dry_run":     "true",
+		"replicas":    "3",
+	}
+	for name, want := range inputs {
+		if got, ok := e.Input(name); !ok || got != want {
+			t.Errorf("Input(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+	if _, ok := e.Input("missing"); ok {
+		t.Error("Input(missing) should not be found")
+	}
+}
+
+func TestReadEvent_MergeGroup(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_EVENT_NAME": "merge_group",
+		"GITHUB_EVENT_PATH": "merge_group.json",
+	}
+
+	e, err := ReadEvent(fixtures, env)
+	if err != nil {
+		t.Fatalf("ReadEvent failed: %v", err)
+	}
+	if !e.IsMergeGroup() || e.MergeGroup.BaseRef != "refs/heads/main" {
+		t.Errorf("MergeGroup = %+v", e.MergeGroup)
+	}
+}
+
+func TestReadEvent_Errors(t *testing.T) {
+	if _, err := ReadEvent(fixtures, map[string]string{}); !errors.Is(err, ErrNoEventPath) {
+		t.Errorf("missing path error = %v, want ErrNoEventPath", err)
+	}
+
+	env := map[string]string{"GITHUB_EVENT_PATH": "missing.json"}
+	if _, err := ReadEvent(fixtures, env); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file error = %v, want fs.ErrNotExist", err)
+	}
+
+	broken := fstest.MapFS{"event.json": {Data: []byte("{")}}
+	env = map[string]string{"GITHUB_EVENT_PATH": "event.json"}
+	if _, err := ReadEvent(broken, env); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+}
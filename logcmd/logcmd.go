@@ -0,0 +1,282 @@
+package logcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/startracex/ciinfo"
+)
+
+type level string
+
+const (
+	levelError   level = "error"
+	levelWarning level = "warning"
+	levelNotice  level = "notice"
+)
+
+type dialect interface {
+	annotate(lv level, file string, line int, msg string) string
+	group(name string, now time.Time) string
+	endGroup(name string, now time.Time) string
+	mask(value string) string
+}
+
+var dialects = map[string]dialect{
+	"GITHUB_ACTIONS":  github{},
+	"GITEA_ACTIONS":   github{},
+	"AZURE_PIPELINES": azure{},
+	"TEAMCITY":        teamcity{},
+	"GITLAB":          gitlab{},
+	"BUILDKITE":       buildkite{},
+	"TRAVIS":          travis{},
+}
+
+// Logger writes vendor-specific log commands. Commands for vendors without
+// a dialect, or outside CI, are rendered as plain text.
+type Logger struct {
+	mu      sync.Mutex
+	w       io.Writer
+	dialect dialect
+	groups  []string
+	now     func() time.Time
+	getenv  func(string) string
+	run     func(stdin string, name string, args ...string) error
+}
+
+// New returns a Logger writing to w in the dialect of the vendor whose
+// constant is id, as reported by ciinfo.Info.ID.
+func New(w io.Writer, id string) *Logger {
+	d, ok := dialects[id]
+	if !ok {
+		d = plain{}
+	}
+	return &Logger{w: w, dialect: d, now: time.Now, getenv: os.Getenv, run: runCommand}
+}
+
+// Default returns a Logger writing to standard output for the detected vendor.
+func Default() *Logger {
+	return New(os.Stdout, ciinfo.GetInfo().ID)
+}
+
+func (l *Logger) Error(file string, line int, msg string) {
+	l.write(l.dialect.annotate(levelError, file, line, msg))
+}
+
+func (l *Logger) Warning(file string, line int, msg string) {
+	l.write(l.dialect.annotate(levelWarning, file, line, msg))
+}
+
+func (l *Logger) Notice(file string, line int, msg string) {
+	l.write(l.dialect.annotate(levelNotice, file, line, msg))
+}
+
+func (l *Logger) Group(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.groups = append(l.groups, name)
+	io.WriteString(l.w, l.dialect.group(name, l.now()))
+}
+
+// EndGroup closes the innermost open group. It does nothing when no group is
+// open.
+func (l *Logger) EndGroup() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.groups) == 0 {
+		return
+	}
+	name := l.groups[len(l.groups)-1]
+	l.groups = l.groups[:len(l.groups)-1]
+	io.WriteString(l.w, l.dialect.endGroup(name, l.now()))
+}
+
+// Mask asks the vendor to hide value in the rest of the log. Vendors without
+// a masking command ignore it.
+func (l *Logger) Mask(value string) {
+	if value == "" {
+		return
+	}
+	l.write(l.dialect.mask(value))
+}
+
+func (l *Logger) write(s string) {
+	if s == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, s)
+}
+
+func location(file string, line int) string {
+	switch {
+	case file == "":
+		return ""
+	case line > 0:
+		return fmt.Sprintf("%s:%d: ", file, line)
+	}
+	return file + ": "
+}
+
+type plain struct{}
+
+func (plain) annotate(lv level, file string, line int, msg string) string {
+	return fmt.Sprintf("%s%s: %s\n", location(file, line), lv, msg)
+}
+
+func (plain) group(name string, _ time.Time) string { return name + "\n" }
+
+func (plain) endGroup(string, time.Time) string { return "" }
+
+func (plain) mask(string) string { return "" }
+
+type github struct{}
+
+var (
+	githubData     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubProperty = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func (github) annotate(lv level, file string, line int, msg string) string {
+	var props []string
+	if file != "" {
+		props = append(props, "file="+githubProperty.Replace(file))
+		if line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", line))
+		}
+	}
+	cmd := string(lv)
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return fmt.Sprintf("::%s::%s\n", cmd, githubData.Replace(msg))
+}
+
+func (github) group(name string, _ time.Time) string {
+	return "::group::" + githubData.Replace(name) + "\n"
+}
+
+func (github) endGroup(string, time.Time) string { return "::endgroup::\n" }
+
+func (github) mask(value string) string {
+	return "::add-mask::" + githubData.Replace(value) + "\n"
+}
+
+type azure struct{}
+
+var azureEscape = strings.NewReplacer("%", "%AZP25", ";", "%3B", "\r", "%0D", "\n", "%0A", "]", "%5D")
+
+func (azure) annotate(lv level, file string, line int, msg string) string {
+	if lv == levelNotice {
+		return "##[section]" + azureEscape.Replace(location(file, line)+msg) + "\n"
+	}
+
+	props := "type=" + string(lv) + ";"
+	if file != "" {
+		props += "sourcepath=" + azureEscape.Replace(file) + ";"
+		if line > 0 {
+			props += fmt.Sprintf("linenumber=%d;", line)
+		}
+	}
+	return "##vso[task.logissue " + props + "]" + azureEscape.Replace(msg) + "\n"
+}
+
+func (azure) group(name string, _ time.Time) string {
+	return "##[group]" + azureEscape.Replace(name) + "\n"
+}
+
+func (azure) endGroup(string, time.Time) string { return "##[endgroup]\n" }
+
+func (azure) mask(value string) string {
+	return "##vso[task.setsecret]" + azureEscape.Replace(value) + "\n"
+}
+
+type teamcity struct{}
+
+var teamcityEscape = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+func (teamcity) annotate(lv level, file string, line int, msg string) string {
+	status := map[level]string{
+		levelError:   "ERROR",
+		levelWarning: "WARNING",
+		levelNotice:  "NORMAL",
+	}[lv]
+	return fmt.Sprintf("##teamcity[message text='%s' status='%s']\n",
+		teamcityEscape.Replace(location(file, line)+msg), status)
+}
+
+func (teamcity) group(name string, _ time.Time) string {
+	return "##teamcity[blockOpened name='" + teamcityEscape.Replace(name) + "']\n"
+}
+
+func (teamcity) endGroup(name string, _ time.Time) string {
+	return "##teamcity[blockClosed name='" + teamcityEscape.Replace(name) + "']\n"
+}
+
+func (teamcity) mask(string) string { return "" }
+
+type gitlab struct{}
+
+func (gitlab) annotate(lv level, file string, line int, msg string) string {
+	return plain{}.annotate(lv, file, line, msg)
+}
+
+// GitLab section names may only contain letters, digits, '_', '.' and '-'.
+func gitlabSection(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (gitlab) group(name string, now time.Time) string {
+	return fmt.Sprintf("\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s\n", now.Unix(), gitlabSection(name), name)
+}
+
+func (gitlab) endGroup(name string, now time.Time) string {
+	return fmt.Sprintf("\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", now.Unix(), gitlabSection(name))
+}
+
+func (gitlab) mask(string) string { return "" }
+
+type buildkite struct{}
+
+// An error expands the group it is printed in so it is not hidden.
+func (buildkite) annotate(lv level, file string, line int, msg string) string {
+	s := plain{}.annotate(lv, file, line, msg)
+	if lv == levelError {
+		s = "^^^ +++\n" + s
+	}
+	return s
+}
+
+func (buildkite) group(name string, _ time.Time) string { return "--- " + name + "\n" }
+
+func (buildkite) endGroup(string, time.Time) string { return "" }
+
+func (buildkite) mask(string) string { return "" }
+
+type travis struct{}
+
+func (travis) annotate(lv level, file string, line int, msg string) string {
+	return plain{}.annotate(lv, file, line, msg)
+}
+
+func (travis) group(name string, _ time.Time) string {
+	return "travis_fold:start:" + gitlabSection(name) + "\r\x1b[0K" + name + "\n"
+}
+
+func (travis) endGroup(name string, _ time.Time) string {
+	return "travis_fold:end:" + gitlabSection(name) + "\r\x1b[0K"
+}
+
+func (travis) mask(string) string { return "" }
@@ -0,0 +1,89 @@
+package logcmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{
+			id: "GITHUB_ACTIONS",
+			want: "::error file=a%2Cb.go,line=3::bad%0Athing\n" +
+				"::warning file=a.go::careful\n" +
+				"::notice::hello\n" +
+				"::group::build\n" +
+				"::endgroup::\n" +
+				"::add-mask::s3cr3t\n",
+		},
+		{
+			id: "AZURE_PIPELINES",
+			want: "##vso[task.logissue type=error;sourcepath=a,b.go;linenumber=3;]bad%0Athing\n" +
+				"##vso[task.logissue type=warning;sourcepath=a.go;]careful\n" +
+				"##[section]hello\n" +
+				"##[group]build\n" +
+				"##[endgroup]\n" +
+				"##vso[task.setsecret]s3cr3t\n",
+		},
+		{
+			id: "TEAMCITY",
+			want: "##teamcity[message text='a,b.go:3: bad|nthing' status='ERROR']\n" +
+				"##teamcity[message text='a.go: careful' status='WARNING']\n" +
+				"##teamcity[message text='hello' status='NORMAL']\n" +
+				"##teamcity[blockOpened name='build']\n" +
+				"##teamcity[blockClosed name='build']\n",
+		},
+		{
+			id: "GITLAB",
+			want: "a,b.go:3: error: bad\nthing\n" +
+				"a.go: warning: careful\n" +
+				"notice: hello\n" +
+				"\x1b[0Ksection_start:1700000000:build[collapsed=true]\r\x1b[0Kbuild\n" +
+				"\x1b[0Ksection_end:1700000000:build\r\x1b[0K\n",
+		},
+		{
+			id: "BUILDKITE",
+			want: "^^^ +++\na,b.go:3: error: bad\nthing\n" +
+				"a.go: warning: careful\n" +
+				"notice: hello\n" +
+				"--- build\n",
+		},
+		{
+			id: "",
+			want: "a,b.go:3: error: bad\nthing\n" +
+				"a.go: warning: careful\n" +
+				"notice: hello\n" +
+				"build\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			var b strings.Builder
+			l := New(&b, tt.id)
+			l.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+			l.Error("a,b.go", 3, "bad\nthing")
+			l.Warning("a.go", 0, "careful")
+			l.Notice("", 0, "hello")
+			l.Group("build")
+			l.EndGroup()
+			l.EndGroup()
+			l.Mask("s3cr3t")
+			l.Mask("")
+
+			if got := b.String(); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitLabSectionName(t *testing.T) {
+	if got := gitlabSection("Run tests: unit/v2"); got != "Run_tests__unit_v2" {
+		t.Errorf("gitlabSection = %q", got)
+	}
+}
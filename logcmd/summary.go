@@ -0,0 +1,152 @@
+package logcmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Summary builds a Markdown job summary. The zero value is ready to use.
+type Summary struct {
+	b strings.Builder
+}
+
+func (s *Summary) block(text string) *Summary {
+	if s.b.Len() > 0 {
+		s.b.WriteString("\n")
+	}
+	s.b.WriteString(text)
+	s.b.WriteString("\n")
+	return s
+}
+
+// Heading adds a heading; level is clamped to 1 through 6.
+func (s *Summary) Heading(level int, text string) *Summary {
+	level = min(max(level, 1), 6)
+	return s.block(strings.Repeat("#", level) + " " + text)
+}
+
+// Text adds a paragraph of Markdown.
+func (s *Summary) Text(markdown string) *Summary {
+	return s.block(markdown)
+}
+
+func (s *Summary) List(items ...string) *Summary {
+	var b strings.Builder
+	for _, item := range items {
+		b.WriteString("- " + item + "\n")
+	}
+	return s.block(strings.TrimSuffix(b.String(), "\n"))
+}
+
+func (s *Summary) Code(lang, code string) *Summary {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return s.block(fence + lang + "\n" + strings.TrimSuffix(code, "\n") + "\n" + fence)
+}
+
+var tableCell = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// Table adds a table. Rows shorter than header are padded with empty cells.
+func (s *Summary) Table(header []string, rows ...[]string) *Summary {
+	var b strings.Builder
+	row := func(cells []string) {
+		b.WriteString("|")
+		for i := range header {
+			cell := ""
+			if i < len(cells) {
+				cell = tableCell.Replace(cells[i])
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+	row(header)
+	b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, r := range rows {
+		row(r)
+	}
+	return s.block(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// Details adds a collapsible section whose body is Markdown.
+func (s *Summary) Details(summary, body string) *Summary {
+	return s.block("<details><summary>" + html.EscapeString(summary) + "</summary>\n\n" +
+		strings.TrimSuffix(body, "\n") + "\n\n</details>")
+}
+
+func (s *Summary) String() string {
+	return s.b.String()
+}
+
+// SummaryFile is the artifact written for vendors without a summary
+// mechanism when CIINFO_SUMMARY is not set.
+const SummaryFile = "ci-summary.md"
+
+type summarizer interface {
+	publishSummary(l *Logger, markdown string) error
+}
+
+// PublishSummary publishes s through the vendor's job summary mechanism.
+// Elsewhere it is appended to SummaryFile, or to the file named by
+// CIINFO_SUMMARY, to be kept as a build artifact.
+func (l *Logger) PublishSummary(s *Summary) error {
+	if p, ok := l.dialect.(summarizer); ok {
+		return p.publishSummary(l, s.String())
+	}
+	file := l.getenv("CIINFO_SUMMARY")
+	if file == "" {
+		file = SummaryFile
+	}
+	return appendFile(file, s.String())
+}
+
+func PublishSummary(s *Summary) error { return Default().PublishSummary(s) }
+
+func (github) publishSummary(l *Logger, markdown string) error {
+	file := l.getenv("GITHUB_STEP_SUMMARY")
+	if file == "" {
+		return fmt.Errorf("logcmd: GITHUB_STEP_SUMMARY is not set")
+	}
+	return appendFile(file, markdown)
+}
+
+func (azure) publishSummary(l *Logger, markdown string) error {
+	dir := l.getenv("AGENT_TEMPDIRECTORY")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	f, err := os.CreateTemp(dir, "summary-*.md")
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(markdown)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	name, err := filepath.Abs(f.Name())
+	if err != nil {
+		return err
+	}
+	l.write("##vso[task.uploadsummary]" + azureEscape.Replace(name) + "\n")
+	return nil
+}
+
+func (buildkite) publishSummary(l *Logger, markdown string) error {
+	return l.run(markdown, "buildkite-agent", "annotate", "--style", "info", "--context", "ciinfo", "--append")
+}
+
+func runCommand(stdin string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,87 @@
+package logcmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSummaryMarkdown(t *testing.T) {
+	var s Summary
+	s.Heading(2, "Tests").
+		Text("All **green**.").
+		Table([]string{"Package", "Result"}, []string{"a|b", "ok"}, []string{"c"}).
+		List("one", "two").
+		Code("go", "x := 1\n").
+		Details("Logs <full>", "line\n")
+
+	want := "## Tests\n" +
+		"\nAll **green**.\n" +
+		"\n| Package | Result |\n| --- | --- |\n| a\\|b | ok |\n| c |  |\n" +
+		"\n- one\n- two\n" +
+		"\n```go\nx := 1\n```\n" +
+		"\n<details><summary>Logs &lt;full&gt;</summary>\n\nline\n\n</details>\n"
+	if got := s.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPublishSummary(t *testing.T) {
+	var s Summary
+	s.Heading(1, "Report")
+
+	t.Run("GITHUB_ACTIONS", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "summary")
+		l, _ := newTestLogger(t, "GITHUB_ACTIONS", map[string]string{"GITHUB_STEP_SUMMARY": file})
+		if err := l.PublishSummary(&s); err != nil {
+			t.Fatal(err)
+		}
+		if got := readFile(t, file); got != "# Report\n" {
+			t.Errorf("summary = %q", got)
+		}
+	})
+
+	t.Run("AZURE_PIPELINES", func(t *testing.T) {
+		dir := t.TempDir()
+		l, b := newTestLogger(t, "AZURE_PIPELINES", map[string]string{"AGENT_TEMPDIRECTORY": dir})
+		if err := l.PublishSummary(&s); err != nil {
+			t.Fatal(err)
+		}
+		name, ok := strings.CutPrefix(strings.TrimSuffix(b.String(), "\n"), "##vso[task.uploadsummary]")
+		if !ok || filepath.Dir(name) != dir {
+			t.Fatalf("output = %q", b.String())
+		}
+		if got := readFile(t, name); got != "# Report\n" {
+			t.Errorf("summary = %q", got)
+		}
+	})
+
+	t.Run("BUILDKITE", func(t *testing.T) {
+		l, _ := newTestLogger(t, "BUILDKITE", nil)
+		var gotStdin string
+		var gotArgs []string
+		l.run = func(stdin string, name string, args ...string) error {
+			gotStdin, gotArgs = stdin, append([]string{name}, args...)
+			return nil
+		}
+		if err := l.PublishSummary(&s); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"buildkite-agent", "annotate", "--style", "info", "--context", "ciinfo", "--append"}
+		if gotStdin != "# Report\n" || !reflect.DeepEqual(gotArgs, want) {
+			t.Errorf("ran %q with %q", gotArgs, gotStdin)
+		}
+	})
+
+	t.Run("GITLAB", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "report.md")
+		l, _ := newTestLogger(t, "GITLAB", map[string]string{"CIINFO_SUMMARY": file})
+		if err := l.PublishSummary(&s); err != nil {
+			t.Fatal(err)
+		}
+		if got := readFile(t, file); got != "# Report\n" {
+			t.Errorf("summary = %q", got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package logcmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupported = errors.New("logcmd: not supported by this vendor")
+
+type variable int
+
+const (
+	varOutput variable = iota
+	varEnv
+	varPath
+)
+
+// A variables dialect renders a variable either as text for the log, or,
+// when file is set, as text to append to that file.
+type variables interface {
+	variable(kind variable, name, value string, getenv func(string) string) (file, text string, err error)
+}
+
+var fileMu sync.Mutex
+
+// SetOutput sets a step or job output that later steps can read.
+func (l *Logger) SetOutput(name, value string) error {
+	return l.setVariable(varOutput, name, value)
+}
+
+// ExportEnv sets an environment variable for the following steps.
+func (l *Logger) ExportEnv(name, value string) error {
+	return l.setVariable(varEnv, name, value)
+}
+
+// AddPath prepends dir to PATH for the following steps.
+func (l *Logger) AddPath(dir string) error {
+	return l.setVariable(varPath, "", dir)
+}
+
+func SetOutput(name, value string) error { return Default().SetOutput(name, value) }
+
+func ExportEnv(name, value string) error { return Default().ExportEnv(name, value) }
+
+func AddPath(dir string) error { return Default().AddPath(dir) }
+
+func (l *Logger) setVariable(kind variable, name, value string) error {
+	if kind != varPath && (name == "" || strings.ContainsAny(name, "=\r\n")) {
+		return fmt.Errorf("logcmd: invalid variable name %q", name)
+	}
+	vs, ok := l.dialect.(variables)
+	if !ok {
+		return ErrUnsupported
+	}
+	file, text, err := vs.variable(kind, name, value, l.getenv)
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		l.write(text)
+		return nil
+	}
+	return appendFile(file, text)
+}
+
+func appendFile(name, text string) error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (github) variable(kind variable, name, value string, getenv func(string) string) (string, string, error) {
+	key := map[variable]string{
+		varOutput: "GITHUB_OUTPUT",
+		varEnv:    "GITHUB_ENV",
+		varPath:   "GITHUB_PATH",
+	}[kind]
+	file := getenv(key)
+	if file == "" {
+		return "", "", fmt.Errorf("logcmd: %s is not set", key)
+	}
+
+	if kind == varPath {
+		if strings.ContainsAny(value, "\r\n") {
+			return "", "", fmt.Errorf("logcmd: invalid path %q", value)
+		}
+		return file, value + "\n", nil
+	}
+	if !strings.ContainsAny(value, "\r\n") {
+		return file, name + "=" + value + "\n", nil
+	}
+	delim, err := delimiter()
+	if err != nil {
+		return "", "", err
+	}
+	return file, name + "<<" + delim + "\n" + value + "\n" + delim + "\n", nil
+}
+
+func delimiter() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(b), nil
+}
+
+func (azure) variable(kind variable, name, value string, _ func(string) string) (string, string, error) {
+	switch kind {
+	case varOutput:
+		return "", "##vso[task.setvariable variable=" + azureEscape.Replace(name) + ";isoutput=true]" + azureEscape.Replace(value) + "\n", nil
+	case varEnv:
+		return "", "##vso[task.setvariable variable=" + azureEscape.Replace(name) + "]" + azureEscape.Replace(value) + "\n", nil
+	}
+	return "", "##vso[task.prependpath]" + azureEscape.Replace(value) + "\n", nil
+}
+
+func (teamcity) variable(kind variable, name, value string, getenv func(string) string) (string, string, error) {
+	switch kind {
+	case varEnv:
+		name = "env." + name
+	case varPath:
+		name = "env.PATH"
+		if path := getenv("PATH"); path != "" {
+			value += string(os.PathListSeparator) + path
+		}
+	}
+	return "", "##teamcity[setParameter name='" + teamcityEscape.Replace(name) + "' value='" + teamcityEscape.Replace(value) + "']\n", nil
+}
+
+// DotenvFile is the GitLab dotenv report written when CIINFO_DOTENV is not
+// set. The job must list it under artifacts:reports:dotenv.
+const DotenvFile = "build.env"
+
+func (gitlab) variable(kind variable, name, value string, getenv func(string) string) (string, string, error) {
+	if kind == varPath {
+		return "", "", ErrUnsupported
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", fmt.Errorf("logcmd: dotenv does not support multiline values (%s)", name)
+	}
+	file := getenv("CIINFO_DOTENV")
+	if file == "" {
+		file = DotenvFile
+	}
+	return file, name + "=" + value + "\n", nil
+}
@@ -0,0 +1,157 @@
+package logcmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestLogger(t *testing.T, id string, env map[string]string) (*Logger, *strings.Builder) {
+	t.Helper()
+	var b strings.Builder
+	l := New(&b, id)
+	l.getenv = func(k string) string { return env[k] }
+	return l, &b
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestGitHubVariables(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{
+		"GITHUB_OUTPUT": filepath.Join(dir, "output"),
+		"GITHUB_ENV":    filepath.Join(dir, "env"),
+		"GITHUB_PATH":   filepath.Join(dir, "path"),
+	}
+	l, _ := newTestLogger(t, "GITHUB_ACTIONS", env)
+
+	if err := l.SetOutput("version", "1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetOutput("notes", "line 1\nline 2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.ExportEnv("GOFLAGS", "-mod=mod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddPath("/opt/bin"); err != nil {
+		t.Fatal(err)
+	}
+
+	output := readFile(t, env["GITHUB_OUTPUT"])
+	re := regexp.MustCompile(`^version=1\.2\.3\nnotes<<(ghadelimiter_[0-9a-f]+)\nline 1\nline 2\n(ghadelimiter_[0-9a-f]+)\n$`)
+	if m := re.FindStringSubmatch(output); m == nil || m[1] != m[2] {
+		t.Errorf("GITHUB_OUTPUT = %q", output)
+	}
+	if got := readFile(t, env["GITHUB_ENV"]); got != "GOFLAGS=-mod=mod\n" {
+		t.Errorf("GITHUB_ENV = %q", got)
+	}
+	if got := readFile(t, env["GITHUB_PATH"]); got != "/opt/bin\n" {
+		t.Errorf("GITHUB_PATH = %q", got)
+	}
+
+	l, _ = newTestLogger(t, "GITHUB_ACTIONS", nil)
+	if err := l.SetOutput("a", "b"); err == nil {
+		t.Error("expected error without GITHUB_OUTPUT")
+	}
+}
+
+func TestGitHubVariablesConcurrent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "output")
+	l, _ := newTestLogger(t, "GITHUB_ACTIONS", map[string]string{"GITHUB_OUTPUT": file})
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if err := l.SetOutput("k", "a\nb"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	re := regexp.MustCompile(`k<<(ghadelimiter_[0-9a-f]+)\na\nb\n(ghadelimiter_[0-9a-f]+)\n`)
+	if n := len(re.FindAllString(readFile(t, file), -1)); n != 20 {
+		t.Errorf("got %d well-formed entries, want 20", n)
+	}
+}
+
+func TestLogVariables(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{
+			id: "AZURE_PIPELINES",
+			want: "##vso[task.setvariable variable=out;isoutput=true]a%0Ab\n" +
+				"##vso[task.setvariable variable=NAME]x%3By\n" +
+				"##vso[task.prependpath]/opt/bin\n",
+		},
+		{
+			id: "TEAMCITY",
+			want: "##teamcity[setParameter name='out' value='a|nb']\n" +
+				"##teamcity[setParameter name='env.NAME' value='x;y']\n" +
+				"##teamcity[setParameter name='env.PATH' value='/opt/bin" + string(os.PathListSeparator) + "/usr/bin']\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			l, b := newTestLogger(t, tt.id, map[string]string{"PATH": "/usr/bin"})
+			for _, err := range []error{
+				l.SetOutput("out", "a\nb"),
+				l.ExportEnv("NAME", "x;y"),
+				l.AddPath("/opt/bin"),
+			} {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitLabDotenv(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vars.env")
+	l, _ := newTestLogger(t, "GITLAB", map[string]string{"CIINFO_DOTENV": file})
+
+	if err := l.SetOutput("VERSION", "1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.ExportEnv("CHANNEL", "beta"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.ExportEnv("NOTES", "a\nb"); err == nil {
+		t.Error("expected error for multiline dotenv value")
+	}
+	if err := l.AddPath("/opt/bin"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("AddPath error = %v", err)
+	}
+	if got := readFile(t, file); got != "VERSION=1.2.3\nCHANNEL=beta\n" {
+		t.Errorf("dotenv = %q", got)
+	}
+}
+
+func TestVariablesUnsupported(t *testing.T) {
+	l, _ := newTestLogger(t, "", nil)
+	if err := l.SetOutput("a", "b"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("SetOutput error = %v", err)
+	}
+	l, _ = newTestLogger(t, "AZURE_PIPELINES", nil)
+	if err := l.ExportEnv("A=B", "c"); err == nil {
+		t.Error("expected error for invalid name")
+	}
+}
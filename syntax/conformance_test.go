@@ -0,0 +1,96 @@
+package syntax
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// The conformance cases run under both the encoding/json v1 decoders and the
+// goexperiment.jsonv2 ones, which must agree on every input.
+var conformance = []struct {
+	data string
+	env  *Env // nil when decoding must fail
+	pr   *PR
+}{
+	{`"A"`, &Env{StrictEqual: "A"}, &PR{StrictEqual: "A"}},
+	{`{"env":"A"}`, &Env{StrictEqual: "A"}, &PR{StrictEqual: "A"}},
+	{`{"env":"A","includes":"x"}`, &Env{StrictEqual: "A", Includes: "x"}, &PR{StrictEqual: "A", EqualsMap: map[string]string{"includes": "x"}}},
+	{`{"env":"A","ne":"0"}`, &Env{StrictEqual: "A", EqualsMap: map[string]string{"ne": "0"}}, &PR{StrictEqual: "A", NotEqual: "0"}},
+	{`{"any":["A","B"]}`, &Env{EqualsAnyOf: []string{"A", "B"}}, &PR{EqualsAnyOf: []string{"A", "B"}}},
+	{`{"A":"1"}`, &Env{EqualsMap: map[string]string{"A": "1"}}, &PR{EqualsMap: map[string]string{"A": "1"}}},
+	{`{"any":["A",{"env":"B"}]}`, &Env{Any: []Env{{StrictEqual: "A"}, {StrictEqual: "B"}}}, &PR{Any: []PR{{StrictEqual: "A"}, {StrictEqual: "B"}}}},
+	{`{"not":"A","all":["B"]}`, &Env{Not: &Env{StrictEqual: "A"}, All: []Env{{StrictEqual: "B"}}}, &PR{Not: &PR{StrictEqual: "A"}, All: []PR{{StrictEqual: "B"}}}},
+	{`{"env":"A","numeric":true}`, &Env{StrictEqual: "A", Predicates: Predicates{Numeric: true}}, &PR{StrictEqual: "A", Predicates: Predicates{Numeric: true}}},
+	{`{}`, &Env{}, &PR{}},
+	{`null`, &Env{}, &PR{}},
+	{`1`, nil, nil},
+	{`true`, nil, nil},
+	{`["A"]`, nil, nil},
+	{`{"env":1}`, nil, nil},
+	{`{"A":1}`, nil, nil},
+	{`{"A":null}`, &Env{EqualsMap: map[string]string{"A": ""}}, &PR{EqualsMap: map[string]string{"A": ""}}},
+	{`{"any":"A"}`, nil, nil},
+	{`{"any":[1]}`, nil, nil},
+	{`{"numeric":"yes"}`, nil, nil},
+	{`{"regex":"("}`, nil, nil},
+	{`{"not":1}`, nil, nil},
+	{`{"env":"A"`, nil, nil},
+}
+
+func TestConformance(t *testing.T) {
+	for _, tt := range conformance {
+		t.Run(tt.data, func(t *testing.T) {
+			var env Env
+			err := json.Unmarshal([]byte(tt.data), &env)
+			switch {
+			case tt.env == nil && err == nil:
+				t.Errorf("Env: expected error, got %+v", env)
+			case tt.env != nil && err != nil:
+				t.Errorf("Env: %v", err)
+			case tt.env != nil && !reflect.DeepEqual(env, *tt.env):
+				t.Errorf("Env = %#v, want %#v", env, *tt.env)
+			}
+
+			var pr PR
+			err = json.Unmarshal([]byte(tt.data), &pr)
+			switch {
+			case tt.pr == nil && err == nil:
+				t.Errorf("PR: expected error, got %+v", pr)
+			case tt.pr != nil && err != nil:
+				t.Errorf("PR: %v", err)
+			case tt.pr != nil && !reflect.DeepEqual(pr, *tt.pr):
+				t.Errorf("PR = %#v, want %#v", pr, *tt.pr)
+			}
+		})
+	}
+}
+
+func TestEnvListConformance(t *testing.T) {
+	tests := []struct {
+		data string
+		want EnvList
+		fail bool
+	}{
+		{`"A"`, EnvList{{StrictEqual: "A"}}, false},
+		{`["A",{"env":"B"}]`, EnvList{{StrictEqual: "A"}, {StrictEqual: "B"}}, false},
+		{`[]`, EnvList{}, false},
+		{`null`, nil, false},
+		{`[1]`, nil, true},
+		{`1`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var l EnvList
+			err := json.Unmarshal([]byte(tt.data), &l)
+			switch {
+			case tt.fail && err == nil:
+				t.Errorf("expected error, got %+v", l)
+			case !tt.fail && err != nil:
+				t.Error(err)
+			case !tt.fail && !reflect.DeepEqual(l, tt.want):
+				t.Errorf("got %#v, want %#v", l, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package syntax
+
+import "strings"
+
+// Environment is the source rules read variables from. Lookup reports
+// whether key is defined at all; a defined variable may be empty.
+type Environment interface {
+	Lookup(key string) (string, bool)
+}
+
+// Map is an Environment backed by a map.
+type Map map[string]string
+
+func (m Map) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// Environ is an Environment backed by "KEY=value" entries, as returned by
+// os.Environ. Later entries win, and entries without '=' are ignored.
+type Environ []string
+
+func (e Environ) Lookup(key string) (string, bool) {
+	for i := len(e) - 1; i >= 0; i-- {
+		if v, ok := strings.CutPrefix(e[i], key); ok && v != "" && v[0] == '=' {
+			return v[1:], true
+		}
+	}
+	return "", false
+}
+
+// LookupFunc adapts a function such as os.LookupEnv to Environment.
+type LookupFunc func(key string) (string, bool)
+
+func (f LookupFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// Overlay is an Environment that looks keys up in each layer in turn, so
+// earlier layers shadow later ones.
+type Overlay []Environment
+
+func (o Overlay) Lookup(key string) (string, bool) {
+	for _, env := range o {
+		if v, ok := env.Lookup(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// get returns the value of key, or "" when it is not defined.
+func get(env Environment, key string) string {
+	v, _ := env.Lookup(key)
+	return v
+}
@@ -0,0 +1,56 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestEnvironments(t *testing.T) {
+	tests := []struct {
+		name string
+		env  Environment
+	}{
+		{"Map", Map{"A": "1", "EMPTY": ""}},
+		{"Environ", Environ{"A=0", "EMPTY=", "A=1", "AB=2", "NOEQUALS"}},
+		{"LookupFunc", LookupFunc(Map{"A": "1", "EMPTY": ""}.Lookup)},
+		{"Overlay", Overlay{Map{"A": "1"}, Environ{"A=2", "EMPTY="}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range []struct {
+				key   string
+				value string
+				ok    bool
+			}{
+				{"A", "1", true},
+				{"EMPTY", "", true},
+				{"MISSING", "", false},
+				{"NOEQUALS", "", false},
+			} {
+				if v, ok := tt.env.Lookup(c.key); v != c.value || ok != c.ok {
+					t.Errorf("Lookup(%q) = %q, %v; want %q, %v", c.key, v, ok, c.value, c.ok)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchDoesNotAllocate(t *testing.T) {
+	var env Environment = Environ{"CI=true", "GITHUB_ACTIONS=true", "GITHUB_EVENT_NAME=pull_request", "NODE=/app/.heroku/node/bin/node"}
+	rules := EnvList{
+		{StrictEqual: "GITHUB_ACTIONS"},
+		{StrictEqual: "NODE", Includes: "/app/.heroku"},
+		{EqualsAnyOf: []string{"MISSING", "CI"}},
+		{EqualsMap: map[string]string{"GITHUB_EVENT_NAME": "pull_request"}},
+		{Not: &Env{StrictEqual: "MISSING"}},
+	}
+	pr := PR{StrictEqual: "GITHUB_EVENT_NAME", EqualsAnyOf: []string{"pull_request", "pull_request_target"}}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if !rules.Match(env) || !pr.Match(env) {
+			t.Fatal("rules did not match")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Match allocated %v times per run", allocs)
+	}
+}
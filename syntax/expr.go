@@ -0,0 +1,635 @@
+package syntax
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// An expression is a boolean formula over variables:
+//
+//	GITHUB_ACTIONS && GITHUB_EVENT_NAME in ["pull_request", "pull_request_target"]
+//	NODE contains "/app/.heroku" || !(CI == "false")
+//
+// A bare variable is true when it is set. Comparisons are ==, !=,
+// in [...], contains, matches (a regular expression), startsWith, endsWith,
+// equalFold, and "is" followed by numeric, nonEmpty, defined (set, possibly
+// to the empty string) or undefined. ! binds tighter than &&, which binds
+// tighter than ||. FOO != "x" means !(FOO == "x") for both Env and PR
+// rules, so it holds when FOO is unset; write FOO is defined && FOO != "x"
+// to require the variable.
+
+// ParseError reports where an expression could not be parsed.
+type ParseError struct {
+	Expr   string
+	Offset int // byte offset into Expr
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("syntax: col %d: %s", e.Offset+1, e.Msg)
+}
+
+func ParseEnv(expr string) (Env, error) {
+	n, err := parse(expr)
+	if err != nil {
+		return Env{}, err
+	}
+	return n.env(), nil
+}
+
+func ParsePR(expr string) (PR, error) {
+	n, err := parse(expr)
+	if err != nil {
+		return PR{}, err
+	}
+	return n.pr(), nil
+}
+
+func MustParseEnv(expr string) Env {
+	r, err := ParseEnv(expr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func MustParsePR(expr string) PR {
+	r, err := ParsePR(expr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokAnd
+	tokOr
+	tokNot
+	tokEq
+	tokNe
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string // identifier, or unquoted string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokIdent:
+		return strconv.Quote(t.text)
+	case tokString:
+		return "string " + strconv.Quote(t.text)
+	}
+	return strconv.Quote(t.text)
+}
+
+func lex(expr string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(expr) && isIdentPart(expr[j]) {
+				j++
+			}
+			toks = append(toks, token{tokIdent, expr[i:j], i})
+			i = j
+			continue
+		case c == '"':
+			j := i + 1
+			for j < len(expr) && expr[j] != '"' {
+				if expr[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(expr) {
+				return nil, &ParseError{expr, i, "unterminated string"}
+			}
+			s, err := strconv.Unquote(expr[i : j+1])
+			if err != nil {
+				return nil, &ParseError{expr, i, "invalid string: " + err.Error()}
+			}
+			toks = append(toks, token{tokString, s, i})
+			i = j + 1
+			continue
+		}
+
+		two := expr[i:min(i+2, len(expr))]
+		switch two {
+		case "&&", "||", "==", "!=":
+			kind := map[string]tokenKind{"&&": tokAnd, "||": tokOr, "==": tokEq, "!=": tokNe}[two]
+			toks = append(toks, token{kind, two, i})
+			i += 2
+			continue
+		}
+		kind, ok := map[byte]tokenKind{
+			'!': tokNot, '(': tokLParen, ')': tokRParen,
+			'[': tokLBrack, ']': tokRBrack, ',': tokComma,
+		}[c]
+		if !ok {
+			return nil, &ParseError{expr, i, fmt.Sprintf("unexpected character %q", c)}
+		}
+		toks = append(toks, token{kind, string(c), i})
+		i++
+	}
+	return append(toks, token{tokEOF, "", len(expr)}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// node is a parsed expression. op is "&&", "||" or "!" for the boolean
+// operators, and otherwise the comparison applied to key; "" tests presence.
+type node struct {
+	op   string
+	key  string
+	args []string
+	kids []*node
+}
+
+type parser struct {
+	expr string
+	toks []token
+	i    int
+}
+
+func parse(expr string) (*node, error) {
+	toks, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expr: expr, toks: toks}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &ParseError{p.expr, t.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.errorf(t, "expected %s, found %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *parser) or() (*node, error) {
+	return p.binary(tokOr, "||", p.and)
+}
+
+func (p *parser) and() (*node, error) {
+	return p.binary(tokAnd, "&&", p.unary)
+}
+
+func (p *parser) binary(kind tokenKind, op string, operand func() (*node, error)) (*node, error) {
+	n, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != kind {
+		return n, nil
+	}
+	kids := []*node{n}
+	for p.peek().kind == kind {
+		p.next()
+		n, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		kids = append(kids, n)
+	}
+	return &node{op: op, kids: kids}, nil
+}
+
+func (p *parser) unary() (*node, error) {
+	switch t := p.peek(); t.kind {
+	case tokNot:
+		p.next()
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{op: "!", kids: []*node{n}}, nil
+	case tokLParen:
+		p.next()
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return n, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (*node, error) {
+	key, err := p.expect(tokIdent, "variable")
+	if err != nil {
+		return nil, err
+	}
+	n := &node{key: key.text}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokEq || t.kind == tokNe:
+		p.next()
+		s, err := p.expect(tokString, "string")
+		if err != nil {
+			return nil, err
+		}
+		n.op, n.args = t.text, []string{s.text}
+
+	case t.kind == tokIdent && t.text == "in":
+		p.next()
+		if _, err := p.expect(tokLBrack, `"["`); err != nil {
+			return nil, err
+		}
+		for {
+			s, err := p.expect(tokString, "string")
+			if err != nil {
+				return nil, err
+			}
+			n.args = append(n.args, s.text)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+		if _, err := p.expect(tokRBrack, `"]"`); err != nil {
+			return nil, err
+		}
+		n.op = "in"
+
+	case t.kind == tokIdent && t.text == "is":
+		p.next()
+		w := p.next()
+		if w.kind != tokIdent || !slices.Contains([]string{"numeric", "nonEmpty", "defined", "undefined"}, w.text) {
+			return nil, p.errorf(w, "expected numeric, nonEmpty, defined or undefined, found %s", w)
+		}
+		n.op = w.text
+
+	case t.kind == tokIdent && slices.Contains([]string{"contains", "matches", "startsWith", "endsWith", "equalFold"}, t.text):
+		p.next()
+		s, err := p.expect(tokString, "string")
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "matches" {
+			if _, err := compile(s.text); err != nil {
+				return nil, p.errorf(s, "invalid regular expression: %v", err)
+			}
+		}
+		n.op, n.args = t.text, []string{s.text}
+
+	case t.kind == tokIdent:
+		return nil, p.errorf(t, "unknown operator %s", t)
+	}
+	return n, nil
+}
+
+func (n *node) env() Env {
+	switch n.op {
+	case "&&":
+		r := Env{}
+		for _, k := range n.kids {
+			r.All = append(r.All, k.env())
+		}
+		return r
+	case "||":
+		if keys, ok := n.presenceKeys(); ok {
+			return Env{EqualsAnyOf: keys}
+		}
+		r := Env{}
+		for _, k := range n.kids {
+			r.Any = append(r.Any, k.env())
+		}
+		return r
+	case "!":
+		kid := n.kids[0].env()
+		return Env{Not: &kid}
+	case "":
+		return Env{StrictEqual: n.key}
+	case "==":
+		return Env{EqualsMap: map[string]string{n.key: n.args[0]}}
+	case "!=":
+		return Env{Not: &Env{EqualsMap: map[string]string{n.key: n.args[0]}}}
+	case "in":
+		r := Env{}
+		for _, v := range n.args {
+			r.Any = append(r.Any, Env{EqualsMap: map[string]string{n.key: v}})
+		}
+		return r
+	case "contains":
+		return Env{StrictEqual: n.key, Includes: n.args[0]}
+	}
+	return Env{StrictEqual: n.key, Predicates: n.predicates()}
+}
+
+func (n *node) pr() PR {
+	switch n.op {
+	case "&&":
+		r := PR{}
+		for _, k := range n.kids {
+			r.All = append(r.All, k.pr())
+		}
+		return r
+	case "||":
+		if keys, ok := n.presenceKeys(); ok {
+			return PR{EqualsAnyOf: keys}
+		}
+		r := PR{}
+		for _, k := range n.kids {
+			r.Any = append(r.Any, k.pr())
+		}
+		return r
+	case "!":
+		kid := n.kids[0].pr()
+		return PR{Not: &kid}
+	case "":
+		return PR{StrictEqual: n.key}
+	case "==":
+		return PR{EqualsMap: map[string]string{n.key: n.args[0]}}
+	case "!=":
+		return PR{Not: &PR{EqualsMap: map[string]string{n.key: n.args[0]}}}
+	case "in":
+		r := PR{}
+		for _, v := range n.args {
+			r.Any = append(r.Any, PR{EqualsMap: map[string]string{n.key: v}})
+		}
+		return r
+	case "contains":
+		return PR{StrictEqual: n.key, Predicates: Predicates{Regex: regexp.QuoteMeta(n.args[0])}}
+	}
+	return PR{StrictEqual: n.key, Predicates: n.predicates()}
+}
+
+func (n *node) predicates() Predicates {
+	switch n.op {
+	case "matches":
+		return Predicates{Regex: n.args[0]}
+	case "startsWith":
+		return Predicates{Prefix: n.args[0]}
+	case "endsWith":
+		return Predicates{Suffix: n.args[0]}
+	case "equalFold":
+		return Predicates{EqualFold: n.args[0]}
+	case "nonEmpty":
+		return Predicates{NonEmpty: true}
+	case "defined":
+		return Predicates{Defined: true}
+	case "undefined":
+		return Predicates{Unset: true}
+	}
+	return Predicates{Numeric: true}
+}
+
+// presenceKeys reports the variables of an || whose operands are all bare
+// variables, which the ci-info "any" list expresses directly.
+func (n *node) presenceKeys() ([]string, bool) {
+	var keys []string
+	for _, k := range n.kids {
+		if k.op != "" || k.kids != nil {
+			return nil, false
+		}
+		keys = append(keys, k.key)
+	}
+	return keys, true
+}
+
+// Formatting. Each rule prints as a conjunction of its parts; prec tells the
+// caller whether the result needs parentheses.
+const (
+	precOr = iota
+	precAnd
+	precCmp
+	precUnary
+)
+
+// part is a formatted condition and how tightly it binds.
+type part struct {
+	s    string
+	prec int
+}
+
+func (r Env) String() string {
+	s, _ := r.format()
+	return s
+}
+
+func (l EnvList) String() string {
+	if len(l) == 0 {
+		return "true"
+	}
+	parts := make([]string, len(l))
+	for i, r := range l {
+		parts[i] = paren(r.format())(precAnd)
+	}
+	return strings.Join(parts, " && ")
+}
+
+func (r PR) String() string {
+	s, _ := r.format()
+	return s
+}
+
+func (r *Env) format() (string, int) {
+	var leaf []part
+	if r.leaf() {
+		leaf = r.formatLeaf()
+	}
+	return formatNode(leaf, r.All, r.Any, r.Not)
+}
+
+func (r *PR) format() (string, int) {
+	var leaf []part
+	if r.leaf() {
+		leaf = r.formatLeaf()
+	}
+	return formatNode(leaf, r.All, r.Any, r.Not)
+}
+
+// formatLeaf returns one part per condition of matchLeaf.
+func (r *Env) formatLeaf() []part {
+	var parts []part
+	if r.StrictEqual != "" {
+		switch {
+		case !r.Predicates.empty():
+			parts = r.Predicates.format(r.StrictEqual)
+		case r.Includes == "":
+			parts = append(parts, part{r.StrictEqual, precUnary})
+		}
+		if r.Includes != "" {
+			parts = append(parts, part{r.StrictEqual + " contains " + strconv.Quote(r.Includes), precCmp})
+		}
+	}
+	if len(r.EqualsAnyOf) > 0 {
+		parts = append(parts, formatAnyOf(r.EqualsAnyOf))
+	}
+	return append(parts, formatEquals(r.EqualsMap)...)
+}
+
+func (r *PR) formatLeaf() []part {
+	var parts []part
+	switch {
+	case r.StrictEqual == "" && len(r.EqualsAnyOf) > 0:
+		parts = append(parts, formatAnyOf(r.EqualsAnyOf))
+	case r.StrictEqual != "":
+		if len(r.EqualsAnyOf) > 0 {
+			quoted := make([]string, len(r.EqualsAnyOf))
+			for i, v := range r.EqualsAnyOf {
+				quoted[i] = strconv.Quote(v)
+			}
+			parts = append(parts, part{r.StrictEqual + " in [" + strings.Join(quoted, ", ") + "]", precCmp})
+		}
+		if r.NotEqual != "" {
+			// NotEqual fails on an unset variable, unlike !=, so say so
+			// unless the list of values already does.
+			if !r.Predicates.Defined && len(r.EqualsAnyOf) == 0 {
+				parts = append(parts, part{r.StrictEqual + " is defined", precCmp})
+			}
+			parts = append(parts, part{r.StrictEqual + " != " + strconv.Quote(r.NotEqual), precCmp})
+		}
+		parts = append(parts, r.Predicates.format(r.StrictEqual)...)
+		if len(parts) == 0 {
+			parts = append(parts, part{r.StrictEqual, precUnary})
+		}
+	}
+	return append(parts, formatEquals(r.EqualsMap)...)
+}
+
+func (p *Predicates) format(key string) []part {
+	if p.Unset {
+		return []part{{key + " is undefined", precCmp}}
+	}
+	var parts []part
+	for _, op := range []struct{ name, arg string }{
+		{"matches", p.Regex},
+		{"startsWith", p.Prefix},
+		{"endsWith", p.Suffix},
+		{"equalFold", p.EqualFold},
+	} {
+		if op.arg != "" {
+			parts = append(parts, part{key + " " + op.name + " " + strconv.Quote(op.arg), precCmp})
+		}
+	}
+	for _, op := range []struct {
+		name string
+		set  bool
+	}{
+		{"numeric", p.Numeric},
+		{"nonEmpty", p.NonEmpty},
+		{"defined", p.Defined},
+	} {
+		if op.set {
+			parts = append(parts, part{key + " is " + op.name, precCmp})
+		}
+	}
+	return parts
+}
+
+func formatAnyOf(keys []string) part {
+	if len(keys) == 1 {
+		return part{keys[0], precUnary}
+	}
+	return part{strings.Join(keys, " || "), precOr}
+}
+
+func formatEquals(eq map[string]string) []part {
+	var parts []part
+	for _, k := range slices.Sorted(maps.Keys(eq)) {
+		parts = append(parts, part{k + " == " + strconv.Quote(eq[k]), precCmp})
+	}
+	return parts
+}
+
+func formatNode[T any, P interface {
+	*T
+	format() (string, int)
+}](leaf []part, all, some []T, not P) (string, int) {
+	parts := leaf
+	for i := range all {
+		s, prec := P(&all[i]).format()
+		parts = append(parts, part{s, prec})
+	}
+	if len(some) > 0 {
+		alts := make([]string, len(some))
+		for i := range some {
+			alts[i] = paren(P(&some[i]).format())(precOr)
+		}
+		parts = append(parts, part{strings.Join(alts, " || "), precOr})
+	}
+	if not != nil {
+		parts = append(parts, part{"!" + paren(not.format())(precUnary), precUnary})
+	}
+
+	switch len(parts) {
+	case 0:
+		return "false", precUnary
+	case 1:
+		return parts[0].s, parts[0].prec
+	}
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = paren(p.s, p.prec)(precAnd)
+	}
+	return strings.Join(out, " && "), precAnd
+}
+
+// paren returns a function wrapping s in parentheses when it binds looser
+// than the context it is placed in.
+func paren(s string, prec int) func(context int) string {
+	return func(context int) string {
+		if prec < context {
+			return "(" + s + ")"
+		}
+		return s
+	}
+}
@@ -0,0 +1,224 @@
+package syntax
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	tests := []struct {
+		expr string
+		want Env
+		str  string
+	}{
+		{
+			expr: `GITHUB_ACTIONS`,
+			want: Env{StrictEqual: "GITHUB_ACTIONS"},
+		},
+		{
+			expr: `NODE contains "/app/.heroku"`,
+			want: Env{StrictEqual: "NODE", Includes: "/app/.heroku"},
+		},
+		{
+			expr: `CI_NAME == "codeship"`,
+			want: Env{EqualsMap: map[string]string{"CI_NAME": "codeship"}},
+		},
+		{
+			expr: `A || B || C`,
+			want: Env{EqualsAnyOf: []string{"A", "B", "C"}},
+		},
+		{
+			expr: `GITHUB_ACTIONS && GITHUB_EVENT_NAME in ["pull_request", "pull_request_target"]`,
+			want: Env{All: []Env{
+				{StrictEqual: "GITHUB_ACTIONS"},
+				{Any: []Env{
+					{EqualsMap: map[string]string{"GITHUB_EVENT_NAME": "pull_request"}},
+					{EqualsMap: map[string]string{"GITHUB_EVENT_NAME": "pull_request_target"}},
+				}},
+			}},
+			str: `GITHUB_ACTIONS && (GITHUB_EVENT_NAME == "pull_request" || GITHUB_EVENT_NAME == "pull_request_target")`,
+		},
+		{
+			expr: `(JENKINS_URL || HUDSON_URL) && !TAG_NAME`,
+			want: Env{All: []Env{
+				{EqualsAnyOf: []string{"JENKINS_URL", "HUDSON_URL"}},
+				{Not: &Env{StrictEqual: "TAG_NAME"}},
+			}},
+		},
+		{
+			expr: `REF matches "^refs/tags/" && N is numeric && HOST endsWith ".com" && P startsWith "x" && CI equalFold "true"`,
+			want: Env{All: []Env{
+				{StrictEqual: "REF", Predicates: Predicates{Regex: "^refs/tags/"}},
+				{StrictEqual: "N", Predicates: Predicates{Numeric: true}},
+				{StrictEqual: "HOST", Predicates: Predicates{Suffix: ".com"}},
+				{StrictEqual: "P", Predicates: Predicates{Prefix: "x"}},
+				{StrictEqual: "CI", Predicates: Predicates{EqualFold: "true"}},
+			}},
+		},
+		{
+			expr: `CI != "false"`,
+			want: Env{Not: &Env{EqualsMap: map[string]string{"CI": "false"}}},
+			str:  `!(CI == "false")`,
+		},
+		{
+			expr: `!(A && B) || C`,
+			want: Env{Any: []Env{
+				{Not: &Env{All: []Env{{StrictEqual: "A"}, {StrictEqual: "B"}}}},
+				{StrictEqual: "C"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseEnv(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseEnv() = %#v, want %#v", got, tt.want)
+			}
+
+			str := tt.str
+			if str == "" {
+				str = tt.expr
+			}
+			if got.String() != str {
+				t.Errorf("String() = %s, want %s", got.String(), str)
+			}
+			again, err := ParseEnv(got.String())
+			if err != nil || !reflect.DeepEqual(again, got) {
+				t.Errorf("String() does not parse back: %#v, %v", again, err)
+			}
+		})
+	}
+}
+
+func TestParsePR(t *testing.T) {
+	tests := []struct {
+		expr string
+		want PR
+		str  string
+	}{
+		{`PR != "false"`, PR{Not: &PR{EqualsMap: map[string]string{"PR": "false"}}}, `!(PR == "false")`},
+		{`!(EVENT == "push") && PR`, PR{All: []PR{
+			{Not: &PR{EqualsMap: map[string]string{"EVENT": "push"}}},
+			{StrictEqual: "PR"},
+		}}, ""},
+		{`EVENT in ["pull_request", "merge_request"]`, PR{Any: []PR{
+			{EqualsMap: map[string]string{"EVENT": "pull_request"}},
+			{EqualsMap: map[string]string{"EVENT": "merge_request"}},
+		}}, `EVENT == "pull_request" || EVENT == "merge_request"`},
+		{`PR_NUMBER || PR_ID`, PR{EqualsAnyOf: []string{"PR_NUMBER", "PR_ID"}}, ""},
+		{`REF contains "a.b"`, PR{StrictEqual: "REF", Predicates: Predicates{Regex: `a\.b`}}, `REF matches "a\\.b"`},
+		{`HEAD is defined && BASE is undefined && N is nonEmpty`, PR{All: []PR{
+			{StrictEqual: "HEAD", Predicates: Predicates{Defined: true}},
+			{StrictEqual: "BASE", Predicates: Predicates{Unset: true}},
+			{StrictEqual: "N", Predicates: Predicates{NonEmpty: true}},
+		}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParsePR(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParsePR() = %#v, want %#v", got, tt.want)
+			}
+			str := tt.str
+			if str == "" {
+				str = tt.expr
+			}
+			if got.String() != str {
+				t.Errorf("String() = %s, want %s", got.String(), str)
+			}
+			again, err := ParsePR(got.String())
+			if err != nil || !reflect.DeepEqual(again, got) {
+				t.Errorf("String() %s does not parse back: %#v, %v", got, again, err)
+			}
+		})
+	}
+}
+
+func TestNotEqualUnset(t *testing.T) {
+	env, pr := MustParseEnv(`PR != "false"`), MustParsePR(`PR != "false"`)
+	for _, m := range []Map{{}, {"PR": ""}, {"PR": "1"}, {"PR": "false"}} {
+		want := m["PR"] != "false" || len(m) == 0
+		if got := env.Match(m); got != want {
+			t.Errorf("Env.Match(%v) = %v, want %v", m, got, want)
+		}
+		if got := pr.Match(m); got != want {
+			t.Errorf("PR.Match(%v) = %v, want %v", m, got, want)
+		}
+	}
+
+	// The NotEqual field, unlike !=, requires the variable.
+	ne := PR{StrictEqual: "PR", NotEqual: "false"}
+	if got, want := ne.String(), `PR is defined && PR != "false"`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	again := MustParsePR(ne.String())
+	for _, m := range []Map{{}, {"PR": ""}, {"PR": "1"}, {"PR": "false"}} {
+		if again.Match(m) != ne.Match(m) {
+			t.Errorf("String() %s disagrees with the rule on %v", ne, m)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+		msg    string
+	}{
+		{``, 0, `expected variable, found end of expression`},
+		{`A &&`, 4, `expected variable, found end of expression`},
+		{`A B`, 2, `unknown operator "B"`},
+		{`(A || B`, 7, `expected ")", found end of expression`},
+		{`A == B`, 5, `expected string, found "B"`},
+		{`A in ["x" "y"]`, 10, `expected "]", found string "y"`},
+		{`A == "x`, 5, `unterminated string`},
+		{`A matches "("`, 10, "invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		{`A is odd`, 5, `expected numeric, nonEmpty, defined or undefined, found "odd"`},
+		{`A & B`, 2, `unexpected character '&'`},
+		{`A) `, 1, `unexpected ")"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseEnv(tt.expr)
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error = %v, want *ParseError", err)
+			}
+			if pe.Offset != tt.offset || pe.Msg != tt.msg {
+				t.Errorf("error at %d %q, want at %d %q", pe.Offset, pe.Msg, tt.offset, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEnvListString(t *testing.T) {
+	l := EnvList{{StrictEqual: "A"}, {EqualsAnyOf: []string{"B", "C"}}, {Any: []Env{{StrictEqual: "D"}, {StrictEqual: "E", Includes: "x"}}}}
+	if got, want := l.String(), `A && (B || C) && (D || E contains "x")`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestInUnset(t *testing.T) {
+	env, pr := MustParseEnv(`X in ["", "a"]`), MustParsePR(`X in ["", "a"]`)
+	field := PR{StrictEqual: "X", EqualsAnyOf: []string{"", "a"}}
+	for _, m := range []Map{{}, {"X": ""}, {"X": "a"}, {"X": "b"}} {
+		_, want := m["X"]
+		want = want && m["X"] != "b"
+		if got := env.Match(m); got != want {
+			t.Errorf("Env.Match(%v) = %v, want %v", m, got, want)
+		}
+		if got := pr.Match(m); got != want {
+			t.Errorf("PR.Match(%v) = %v, want %v", m, got, want)
+		}
+		if got := field.Match(m); got != want {
+			t.Errorf("EqualsAnyOf values: Match(%v) = %v, want %v", m, got, want)
+		}
+	}
+}
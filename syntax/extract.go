@@ -0,0 +1,51 @@
+package syntax
+
+import (
+	"os"
+	"strings"
+)
+
+type Extract []Source
+
+type Source struct {
+	Env        string  `json:"env,omitempty"`
+	Template   string  `json:"template,omitempty"`
+	TrimPrefix string  `json:"trimPrefix,omitempty"`
+	If         EnvList `json:"if,omitempty"`
+}
+
+func (s *Source) Value(env Environment) string {
+	if len(s.If) > 0 && !s.If.Match(env) {
+		return ""
+	}
+
+	var val string
+	switch {
+	case s.Template != "":
+		missing := false
+		val = os.Expand(s.Template, func(k string) string {
+			v := get(env, k)
+			if v == "" {
+				missing = true
+			}
+			return v
+		})
+		if missing {
+			return ""
+		}
+
+	case s.Env != "":
+		val = get(env, s.Env)
+	}
+
+	return strings.TrimPrefix(val, s.TrimPrefix)
+}
+
+func (x *Extract) Value(env Environment) string {
+	for i := range *x {
+		if val := (*x)[i].Value(env); val != "" {
+			return val
+		}
+	}
+	return ""
+}
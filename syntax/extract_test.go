@@ -0,0 +1,70 @@
+package syntax
+
+import "testing"
+
+func TestExtractValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		extract Extract
+		data    map[string]string
+		want    string
+	}{
+		{
+			name:    "Env",
+			extract: Extract{{Env: "FOO"}},
+			data:    map[string]string{"FOO": "1"},
+			want:    "1",
+		},
+		{
+			name:    "first non-empty wins",
+			extract: Extract{{Env: "FOO"}, {Env: "BAR"}},
+			data:    map[string]string{"FOO": "", "BAR": "2"},
+			want:    "2",
+		},
+		{
+			name:    "TrimPrefix",
+			extract: Extract{{Env: "REF", TrimPrefix: "refs/heads/"}},
+			data:    map[string]string{"REF": "refs/heads/main"},
+			want:    "main",
+		},
+		{
+			name:    "Template",
+			extract: Extract{{Template: "${HOST}/runs/${ID}"}},
+			data:    map[string]string{"HOST": "https://ci", "ID": "7"},
+			want:    "https://ci/runs/7",
+		},
+		{
+			name:    "Template with missing variable",
+			extract: Extract{{Template: "${HOST}/runs/${ID}"}},
+			data:    map[string]string{"HOST": "https://ci"},
+			want:    "",
+		},
+		{
+			name:    "If match",
+			extract: Extract{{Env: "NAME", If: EnvList{{EqualsMap: map[string]string{"TYPE": "tag"}}}}},
+			data:    map[string]string{"NAME": "v1", "TYPE": "tag"},
+			want:    "v1",
+		},
+		{
+			name:    "If mismatch",
+			extract: Extract{{Env: "NAME", If: EnvList{{EqualsMap: map[string]string{"TYPE": "tag"}}}}},
+			data:    map[string]string{"NAME": "main", "TYPE": "branch"},
+			want:    "",
+		},
+		{
+			name:    "Empty Extract",
+			extract: nil,
+			data:    map[string]string{"FOO": "1"},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.extract.Value(Map(tt.data))
+			if got != tt.want {
+				t.Errorf("Extract.Value() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package syntax
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// member is one key of the compact ci-info object form, kept in the order
+// it is written out.
+type member struct {
+	key   string
+	value any
+}
+
+func (r *Env) shorthand() bool {
+	return r.StrictEqual != "" && r.Includes == "" && len(r.EqualsAnyOf) == 0 && len(r.EqualsMap) == 0 && r.Predicates.empty() && !r.tree()
+}
+
+func (r *Env) members() []member {
+	var ms []member
+	if r.StrictEqual != "" {
+		ms = append(ms, member{"env", r.StrictEqual})
+	}
+	if r.Includes != "" {
+		ms = append(ms, member{"includes", r.Includes})
+	}
+	ms = r.Predicates.appendMembers(ms)
+	all, anyOf := r.All, r.EqualsAnyOf
+	if len(r.Any) > 0 && len(anyOf) > 0 {
+		// "any" holds the subrules, so the presence keys, which must hold
+		// as well, move into an "all" element of their own.
+		all = append(slices.Clip(all), Env{EqualsAnyOf: anyOf})
+		anyOf = nil
+	}
+	if len(all) > 0 {
+		ms = append(ms, member{"all", all})
+	}
+	switch {
+	case len(r.Any) > 0:
+		ms = append(ms, member{"any", r.Any})
+	case len(anyOf) > 0:
+		ms = append(ms, member{"any", anyOf})
+	}
+	if r.Not != nil {
+		ms = append(ms, member{"not", r.Not})
+	}
+	return appendEquals(ms, r.EqualsMap)
+}
+
+func (r *PR) shorthand() bool {
+	return r.StrictEqual != "" && r.NotEqual == "" && len(r.EqualsAnyOf) == 0 && len(r.EqualsMap) == 0 && r.Predicates.empty() && !r.tree()
+}
+
+func (r *PR) members() []member {
+	leaf, all := *r, r.All
+	if len(r.Any) > 0 && len(r.EqualsAnyOf) > 0 {
+		// "any" holds the subrules, so the part of the leaf that reads
+		// EqualsAnyOf, as values of env or as presence keys, moves into an
+		// "all" element of its own.
+		moved := PR{EqualsAnyOf: r.EqualsAnyOf}
+		if r.StrictEqual != "" {
+			moved = PR{StrictEqual: r.StrictEqual, NotEqual: r.NotEqual, EqualsAnyOf: r.EqualsAnyOf, Predicates: r.Predicates}
+			leaf.StrictEqual, leaf.NotEqual, leaf.Predicates = "", "", Predicates{}
+		}
+		leaf.EqualsAnyOf = nil
+		all = append(slices.Clip(all), moved)
+	}
+	some := r.Any
+	if leaf.StrictEqual != "" && len(some) > 0 && !slices.ContainsFunc(some, func(c PR) bool { return !c.shorthand() }) {
+		// Next to env, a list of plain names reads as accepted values, so
+		// subrules that would all be written as names move into "all".
+		all = append(slices.Clip(all), PR{Any: some})
+		some = nil
+	}
+
+	var ms []member
+	if leaf.StrictEqual != "" {
+		ms = append(ms, member{"env", leaf.StrictEqual})
+	}
+	if leaf.NotEqual != "" {
+		ms = append(ms, member{"ne", leaf.NotEqual})
+	}
+	ms = leaf.Predicates.appendMembers(ms)
+	if len(all) > 0 {
+		ms = append(ms, member{"all", all})
+	}
+	switch {
+	case len(some) > 0:
+		ms = append(ms, member{"any", some})
+	case len(leaf.EqualsAnyOf) > 0:
+		ms = append(ms, member{"any", leaf.EqualsAnyOf})
+	}
+	if r.Not != nil {
+		ms = append(ms, member{"not", r.Not})
+	}
+	return appendEquals(ms, r.EqualsMap)
+}
+
+func (p *Predicates) appendMembers(ms []member) []member {
+	if p.Regex != "" {
+		ms = append(ms, member{"regex", p.Regex})
+	}
+	if p.Prefix != "" {
+		ms = append(ms, member{"prefix", p.Prefix})
+	}
+	if p.Suffix != "" {
+		ms = append(ms, member{"suffix", p.Suffix})
+	}
+	if p.EqualFold != "" {
+		ms = append(ms, member{"equalFold", p.EqualFold})
+	}
+	if p.Numeric {
+		ms = append(ms, member{"numeric", true})
+	}
+	if p.NonEmpty {
+		ms = append(ms, member{"nonEmpty", true})
+	}
+	if p.Defined {
+		ms = append(ms, member{"defined", true})
+	}
+	if p.Unset {
+		ms = append(ms, member{"unset", true})
+	}
+	return ms
+}
+
+// field returns a pointer to the Predicates field set by an object key, or
+// nil when the key names a variable for EqualsMap.
+func (p *Predicates) field(key string) any {
+	switch key {
+	case "regex":
+		return &p.Regex
+	case "prefix":
+		return &p.Prefix
+	case "suffix":
+		return &p.Suffix
+	case "equalFold":
+		return &p.EqualFold
+	case "numeric":
+		return &p.Numeric
+	case "nonEmpty":
+		return &p.NonEmpty
+	case "defined":
+		return &p.Defined
+	case "unset":
+		return &p.Unset
+	}
+	return nil
+}
+
+func (p *Predicates) validate() error {
+	if p.Regex == "" {
+		return nil
+	}
+	_, err := compile(p.Regex)
+	return err
+}
+
+func appendEquals(ms []member, eq map[string]string) []member {
+	for _, k := range slices.Sorted(maps.Keys(eq)) {
+		ms = append(ms, member{k, eq[k]})
+	}
+	return ms
+}
+
+// operator reports whether key is decoded as an operator rather than as a
+// variable of EqualsMap, for an Env or, with pr set, a PR.
+func operator(key string, pr bool) bool {
+	switch key {
+	case "env", "any", "all", "not":
+		return true
+	case "includes":
+		return !pr
+	case "ne":
+		return pr
+	}
+	return new(Predicates).field(key) != nil
+}
+
+// checkEquals rejects EqualsMap keys that would be written as operators and
+// so decode as a different rule.
+func checkEquals(eq map[string]string, pr bool) error {
+	for _, k := range slices.Sorted(maps.Keys(eq)) {
+		if operator(k, pr) {
+			return fmt.Errorf("equality on %q clashes with the operator of that name", k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build !goexperiment.jsonv2
+// +build !goexperiment.jsonv2
+
+package syntax
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+func (e Env) MarshalJSON() ([]byte, error) {
+	if e.shorthand() {
+		return json.Marshal(e.StrictEqual)
+	}
+	if err := checkEquals(e.EqualsMap, false); err != nil {
+		return nil, err
+	}
+	return marshalMembers(e.members())
+}
+
+func (l EnvList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return l[0].MarshalJSON()
+	}
+	if len(l) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Env(l))
+}
+
+func (p PR) MarshalJSON() ([]byte, error) {
+	if p.shorthand() {
+		return json.Marshal(p.StrictEqual)
+	}
+	if err := checkEquals(p.EqualsMap, true); err != nil {
+		return nil, err
+	}
+	return marshalMembers(p.members())
+}
+
+func marshalMembers(ms []member) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range ms {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
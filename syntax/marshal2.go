@@ -0,0 +1,60 @@
+//go:build goexperiment.jsonv2
+// +build goexperiment.jsonv2
+
+package syntax
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+func (e Env) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if e.shorthand() {
+		return enc.WriteToken(jsontext.String(e.StrictEqual))
+	}
+	if err := checkEquals(e.EqualsMap, false); err != nil {
+		return err
+	}
+	return marshalMembers(enc, e.members())
+}
+
+func (l EnvList) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if len(l) == 1 {
+		return l[0].MarshalJSONTo(enc)
+	}
+
+	if err := enc.WriteToken(jsontext.BeginArray); err != nil {
+		return err
+	}
+	for _, e := range l {
+		if err := e.MarshalJSONTo(enc); err != nil {
+			return err
+		}
+	}
+	return enc.WriteToken(jsontext.EndArray)
+}
+
+func (p PR) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if p.shorthand() {
+		return enc.WriteToken(jsontext.String(p.StrictEqual))
+	}
+	if err := checkEquals(p.EqualsMap, true); err != nil {
+		return err
+	}
+	return marshalMembers(enc, p.members())
+}
+
+func marshalMembers(enc *jsontext.Encoder, ms []member) error {
+	if err := enc.WriteToken(jsontext.BeginObject); err != nil {
+		return err
+	}
+	for _, m := range ms {
+		if err := enc.WriteToken(jsontext.String(m.key)); err != nil {
+			return err
+		}
+		if err := json.MarshalEncode(enc, m.value); err != nil {
+			return err
+		}
+	}
+	return enc.WriteToken(jsontext.EndObject)
+}
@@ -0,0 +1,182 @@
+package syntax
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnvMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		env  Env
+		want string
+	}{
+		{
+			name: "string shorthand",
+			env:  Env{StrictEqual: "FOO"},
+			want: `"FOO"`,
+		},
+		{
+			name: "env and includes",
+			env:  Env{StrictEqual: "PATH", Includes: "/bin"},
+			want: `{"env":"PATH","includes":"/bin"}`,
+		},
+		{
+			name: "any",
+			env:  Env{EqualsAnyOf: []string{"FOO", "BAR"}},
+			want: `{"any":["FOO","BAR"]}`,
+		},
+		{
+			name: "equals map sorted",
+			env:  Env{EqualsMap: map[string]string{"FOO": "1", "BAR": "2"}},
+			want: `{"BAR":"2","FOO":"1"}`,
+		},
+		{
+			name: "mixed",
+			env: Env{
+				StrictEqual: "FOO",
+				Includes:    "bar",
+				EqualsAnyOf: []string{"X"},
+				EqualsMap:   map[string]string{"BAZ": "val"},
+			},
+			want: `{"env":"FOO","includes":"bar","any":["X"],"BAZ":"val"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.env)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+
+			var got Env
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.env) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.env)
+			}
+		})
+	}
+}
+
+func TestEnvListMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		list EnvList
+		want string
+	}{
+		{
+			name: "single element collapsed",
+			list: EnvList{{StrictEqual: "FOO"}},
+			want: `"FOO"`,
+		},
+		{
+			name: "array",
+			list: EnvList{{StrictEqual: "FOO"}, {EqualsAnyOf: []string{"X", "Y"}}},
+			want: `["FOO",{"any":["X","Y"]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.list)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+
+			var got EnvList
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.list) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.list)
+			}
+		})
+	}
+}
+
+func TestPRMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PR
+		want string
+	}{
+		{
+			name: "string shorthand",
+			pr:   PR{StrictEqual: "FOO"},
+			want: `"FOO"`,
+		},
+		{
+			name: "not equal",
+			pr:   PR{StrictEqual: "FOO", NotEqual: "false"},
+			want: `{"env":"FOO","ne":"false"}`,
+		},
+		{
+			name: "env with any values",
+			pr:   PR{StrictEqual: "EVENT", EqualsAnyOf: []string{"A", "B"}},
+			want: `{"env":"EVENT","any":["A","B"]}`,
+		},
+		{
+			name: "equals map",
+			pr:   PR{EqualsMap: map[string]string{"BUILD_REASON": "PullRequest"}},
+			want: `{"BUILD_REASON":"PullRequest"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&tt.pr)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+
+			var got PR
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.pr) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.pr)
+			}
+		})
+	}
+}
+
+func TestMarshalOperatorKeys(t *testing.T) {
+	for _, v := range []any{
+		Env{EqualsMap: map[string]string{"any": "x"}},
+		EnvList{{StrictEqual: "A"}, {EqualsMap: map[string]string{"numeric": "true"}}},
+		PR{EqualsMap: map[string]string{"env": "x"}},
+		&PR{StrictEqual: "A", EqualsMap: map[string]string{"ne": "x"}},
+	} {
+		if data, err := json.Marshal(v); err == nil {
+			t.Errorf("Marshal(%+v) = %s, want an error", v, data)
+		}
+	}
+
+	// Keys that are only operators of the other rule type round-trip.
+	for _, v := range []any{
+		&Env{EqualsMap: map[string]string{"ne": "x"}},
+		&PR{EqualsMap: map[string]string{"includes": "x"}},
+	} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal(%+v): %v", v, err)
+		}
+		got := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		if err := json.Unmarshal(data, got); err != nil || !reflect.DeepEqual(got, v) {
+			t.Errorf("round trip of %s = %+v, %v, want %+v", data, got, err, v)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package syntax
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Predicates test the variable named by a rule's StrictEqual. Every
+// predicate that is set must hold. The variable must be non-empty, or only
+// defined (possibly empty) when Defined is set; Unset instead requires it not
+// to be defined at all.
+type Predicates struct {
+	Regex     string
+	Prefix    string
+	Suffix    string
+	EqualFold string
+	Numeric   bool
+	NonEmpty  bool
+	Defined   bool
+	Unset     bool
+}
+
+func (p *Predicates) empty() bool {
+	return *p == Predicates{}
+}
+
+func (p *Predicates) match(value string, ok bool) bool {
+	if p.Unset {
+		return !ok
+	}
+	if !ok || value == "" && (!p.Defined || p.NonEmpty) {
+		return false
+	}
+	if p.Prefix != "" && !strings.HasPrefix(value, p.Prefix) {
+		return false
+	}
+	if p.Suffix != "" && !strings.HasSuffix(value, p.Suffix) {
+		return false
+	}
+	if p.EqualFold != "" && !strings.EqualFold(value, p.EqualFold) {
+		return false
+	}
+	if p.Numeric && !isNumeric(value) {
+		return false
+	}
+	if p.Regex != "" {
+		re, err := compile(p.Regex)
+		if err != nil || !re.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	f, err := strconv.ParseFloat(s, 64)
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+var regexps sync.Map // string -> *regexp.Regexp
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexps.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexps.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,153 @@
+package syntax
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPredicatesMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		env  Env
+		data map[string]string
+		want bool
+	}{
+		{"regex", Env{StrictEqual: "REF", Predicates: Predicates{Regex: `^refs/tags/v\d+`}}, map[string]string{"REF": "refs/tags/v1.2"}, true},
+		{"regex mismatch", Env{StrictEqual: "REF", Predicates: Predicates{Regex: `^refs/tags/`}}, map[string]string{"REF": "refs/heads/main"}, false},
+		{"invalid regex", Env{StrictEqual: "REF", Predicates: Predicates{Regex: `(`}}, map[string]string{"REF": "("}, false},
+		{"prefix", Env{StrictEqual: "URL", Predicates: Predicates{Prefix: "https://"}}, map[string]string{"URL": "https://ci"}, true},
+		{"prefix mismatch", Env{StrictEqual: "URL", Predicates: Predicates{Prefix: "https://"}}, map[string]string{"URL": "http://ci"}, false},
+		{"suffix", Env{StrictEqual: "HOST", Predicates: Predicates{Suffix: ".example.com"}}, map[string]string{"HOST": "ci.example.com"}, true},
+		{"prefix and suffix", Env{StrictEqual: "HOST", Predicates: Predicates{Prefix: "ci.", Suffix: ".com"}}, map[string]string{"HOST": "ci.example.org"}, false},
+		{"equal fold", Env{StrictEqual: "CI", Predicates: Predicates{EqualFold: "true"}}, map[string]string{"CI": "True"}, true},
+		{"equal fold mismatch", Env{StrictEqual: "CI", Predicates: Predicates{EqualFold: "true"}}, map[string]string{"CI": "yes"}, false},
+		{"numeric", Env{StrictEqual: "N", Predicates: Predicates{Numeric: true}}, map[string]string{"N": "42"}, true},
+		{"numeric rejects text", Env{StrictEqual: "N", Predicates: Predicates{Numeric: true}}, map[string]string{"N": "NaN"}, false},
+		{"numeric requires a value", Env{StrictEqual: "N", Predicates: Predicates{Numeric: true}}, map[string]string{}, false},
+		{"unset", Env{StrictEqual: "CI", Predicates: Predicates{Unset: true}}, map[string]string{}, true},
+		{"unset but set", Env{StrictEqual: "CI", Predicates: Predicates{Unset: true}}, map[string]string{"CI": "1"}, false},
+		{"unset but empty", Env{StrictEqual: "CI", Predicates: Predicates{Unset: true}}, map[string]string{"CI": ""}, false},
+		{"defined empty", Env{StrictEqual: "HEAD", Predicates: Predicates{Defined: true}}, map[string]string{"HEAD": ""}, true},
+		{"defined missing", Env{StrictEqual: "HEAD", Predicates: Predicates{Defined: true}}, map[string]string{}, false},
+		{"defined with regex", Env{StrictEqual: "HEAD", Predicates: Predicates{Defined: true, Regex: "^$"}}, map[string]string{"HEAD": ""}, true},
+		{"non-empty", Env{StrictEqual: "HEAD", Predicates: Predicates{NonEmpty: true}}, map[string]string{"HEAD": "x"}, true},
+		{"non-empty but empty", Env{StrictEqual: "HEAD", Predicates: Predicates{NonEmpty: true}}, map[string]string{"HEAD": ""}, false},
+		{"defined and non-empty", Env{StrictEqual: "HEAD", Predicates: Predicates{Defined: true, NonEmpty: true}}, map[string]string{"HEAD": ""}, false},
+		{"bare rule needs a value", Env{StrictEqual: "HEAD"}, map[string]string{"HEAD": ""}, false},
+		{"with includes", Env{StrictEqual: "URL", Includes: "gitlab", Predicates: Predicates{Prefix: "https://"}}, map[string]string{"URL": "https://github.com"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.env.Match(Map(tt.data)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRPredicatesMatch(t *testing.T) {
+	pr := PR{StrictEqual: "PR", NotEqual: "0", Predicates: Predicates{Numeric: true}}
+	for value, want := range map[string]bool{"12": true, "0": false, "false": false, "": false} {
+		if got := pr.Match(Map{"PR": value}); got != want {
+			t.Errorf("Match(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestPRPresence(t *testing.T) {
+	// TRAVIS_PULL_REQUEST is "false" outside pull requests; an empty value is
+	// still defined and therefore differs from "false".
+	tests := []struct {
+		rule PR
+		data map[string]string
+		want bool
+	}{
+		{PR{StrictEqual: "TRAVIS_PULL_REQUEST", NotEqual: "false"}, map[string]string{"TRAVIS_PULL_REQUEST": ""}, true},
+		{PR{StrictEqual: "TRAVIS_PULL_REQUEST", NotEqual: "false"}, map[string]string{}, false},
+		{PR{StrictEqual: "TRAVIS_PULL_REQUEST", NotEqual: "false", Predicates: Predicates{NonEmpty: true}}, map[string]string{"TRAVIS_PULL_REQUEST": ""}, false},
+		{PR{StrictEqual: "TRAVIS_PULL_REQUEST", Predicates: Predicates{Defined: true}}, map[string]string{"TRAVIS_PULL_REQUEST": ""}, true},
+		{PR{StrictEqual: "TRAVIS_PULL_REQUEST", Predicates: Predicates{Unset: true}}, map[string]string{"TRAVIS_PULL_REQUEST": ""}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Match(Map(tt.data)); got != tt.want {
+			t.Errorf("%s on %v = %v, want %v", tt.rule, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestPredicatesJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		env  Env
+		pr   PR
+	}{
+		{
+			data: `{"env":"REF","regex":"^v\\d+"}`,
+			env:  Env{StrictEqual: "REF", Predicates: Predicates{Regex: `^v\d+`}},
+			pr:   PR{StrictEqual: "REF", Predicates: Predicates{Regex: `^v\d+`}},
+		},
+		{
+			data: `{"env":"HOST","prefix":"ci.","suffix":".com","equalFold":"CI.EXAMPLE.COM"}`,
+			env:  Env{StrictEqual: "HOST", Predicates: Predicates{Prefix: "ci.", Suffix: ".com", EqualFold: "CI.EXAMPLE.COM"}},
+			pr:   PR{StrictEqual: "HOST", Predicates: Predicates{Prefix: "ci.", Suffix: ".com", EqualFold: "CI.EXAMPLE.COM"}},
+		},
+		{
+			data: `{"env":"N","numeric":true}`,
+			env:  Env{StrictEqual: "N", Predicates: Predicates{Numeric: true}},
+			pr:   PR{StrictEqual: "N", Predicates: Predicates{Numeric: true}},
+		},
+		{
+			data: `{"env":"HEAD","nonEmpty":true,"defined":true}`,
+			env:  Env{StrictEqual: "HEAD", Predicates: Predicates{NonEmpty: true, Defined: true}},
+			pr:   PR{StrictEqual: "HEAD", Predicates: Predicates{NonEmpty: true, Defined: true}},
+		},
+		{
+			data: `{"env":"CI","unset":true}`,
+			env:  Env{StrictEqual: "CI", Predicates: Predicates{Unset: true}},
+			pr:   PR{StrictEqual: "CI", Predicates: Predicates{Unset: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var env Env
+			if err := json.Unmarshal([]byte(tt.data), &env); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(env, tt.env) {
+				t.Errorf("Env = %+v, want %+v", env, tt.env)
+			}
+			if out, err := json.Marshal(env); err != nil || string(out) != tt.data {
+				t.Errorf("Marshal(Env) = %s, %v", out, err)
+			}
+
+			var pr PR
+			if err := json.Unmarshal([]byte(tt.data), &pr); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(pr, tt.pr) {
+				t.Errorf("PR = %+v, want %+v", pr, tt.pr)
+			}
+			if out, err := json.Marshal(pr); err != nil || string(out) != tt.data {
+				t.Errorf("Marshal(PR) = %s, %v", out, err)
+			}
+		})
+	}
+}
+
+func TestPredicatesJSONErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"env":"REF","regex":"("}`,
+		`{"env":"N","numeric":"yes"}`,
+		`{"env":"N","prefix":1}`,
+	} {
+		var env Env
+		if err := json.Unmarshal([]byte(data), &env); err == nil {
+			t.Errorf("Env: expected error for %s", data)
+		}
+		var pr PR
+		if err := json.Unmarshal([]byte(data), &pr); err == nil {
+			t.Errorf("PR: expected error for %s", data)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package syntax
+
+import (
+	"cmp"
+	"maps"
+	resyntax "regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// Rule is implemented by *Env, *EnvList and *PR.
+type Rule interface {
+	Match(env Environment) bool
+	formula() formula
+}
+
+// Solve returns a minimal environment in which every rule in match matches
+// and no rule in nomatch does, or false if the solver finds none. Rules with
+// very many alternatives are searched partially, so false is not a proof.
+func Solve(match, nomatch []Rule) (map[string]string, bool) {
+	env, ok, _ := solve(match, nomatch)
+	return env, ok
+}
+
+// Unsatisfiable reports whether no environment makes every rule in match
+// match and no rule in nomatch. Unlike a false result from Solve it is a
+// proof: rules that test values by pattern, substring or number, or that
+// have too many alternatives to search, are never reported unsatisfiable.
+func Unsatisfiable(match, nomatch []Rule) bool {
+	_, ok, complete := solve(match, nomatch)
+	return !ok && complete
+}
+
+// solve is Solve, also reporting whether the search was complete, so that
+// finding nothing proves there is nothing to find.
+func solve(match, nomatch []Rule) (map[string]string, bool, bool) {
+	parts := make([]formula, 0, len(match)+len(nomatch))
+	for _, r := range match {
+		parts = append(parts, r.formula())
+	}
+	for _, r := range nomatch {
+		parts = append(parts, r.formula().not())
+	}
+	alts, complete := terms(and(parts...))
+	slices.SortStableFunc(alts, func(a, b []*check) int { return cmp.Compare(countKeys(a), countKeys(b)) })
+	for _, checks := range alts {
+		env, ok := assign(checks)
+		if ok && matchesAll(Map(env), match, true) && matchesAll(Map(env), nomatch, false) {
+			return env, true, true
+		}
+		// A failed exact alternative has no solution; anything else
+		// might.
+		complete = complete && !ok && !slices.ContainsFunc(checks, func(c *check) bool { return !c.exact })
+	}
+	return nil, false, complete
+}
+
+func matchesAll(env Environment, rules []Rule, want bool) bool {
+	for _, r := range rules {
+		if r.Match(env) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Satisfy returns a minimal environment in which r matches, or false if the
+// solver finds none. False means no witness was found, not that the rule
+// can never match; Unsatisfiable tells the two apart.
+func (r *Env) Satisfy() (map[string]string, bool) {
+	return Solve([]Rule{r}, nil)
+}
+
+// Violate returns a minimal environment in which r does not match, or false
+// if the solver finds none, which is not a proof either.
+func (r *Env) Violate() (map[string]string, bool) {
+	return Solve(nil, []Rule{r})
+}
+
+func (l *EnvList) Satisfy() (map[string]string, bool) {
+	return Solve([]Rule{l}, nil)
+}
+
+func (l *EnvList) Violate() (map[string]string, bool) {
+	return Solve(nil, []Rule{l})
+}
+
+func (r *PR) Satisfy() (map[string]string, bool) {
+	return Solve([]Rule{r}, nil)
+}
+
+func (r *PR) Violate() (map[string]string, bool) {
+	return Solve(nil, []Rule{r})
+}
+
+// formula is a boolean combination of checks on single variables. A leaf
+// node has a check; other nodes combine their children with op.
+type formula struct {
+	op    byte // '&' or '|'
+	kids  []formula
+	check *check
+}
+
+// check tests one variable. ok reports whether it is defined. An exact
+// check only tells unset, empty, its candidates and other values apart, so
+// pick always finds a value for it when there is one.
+type check struct {
+	key        string
+	fn         func(val string, ok bool) bool
+	candidates []string
+	negated    bool
+	exact      bool
+}
+
+func (c *check) holds(val string, ok bool) bool {
+	return c.fn(val, ok) != c.negated
+}
+
+var (
+	top    = formula{op: '&'}
+	bottom = formula{op: '|'}
+)
+
+func and(kids ...formula) formula { return formula{op: '&', kids: kids} }
+func or(kids ...formula) formula  { return formula{op: '|', kids: kids} }
+
+func atom(key string, candidates []string, fn func(val string, ok bool) bool) formula {
+	return formula{check: &check{key: key, fn: fn, candidates: candidates}}
+}
+
+func exactAtom(key string, candidates []string, fn func(val string, ok bool) bool) formula {
+	return formula{check: &check{key: key, fn: fn, candidates: candidates, exact: true}}
+}
+
+func (f formula) not() formula {
+	if f.check != nil {
+		c := *f.check
+		c.negated = !c.negated
+		return formula{check: &c}
+	}
+	out := formula{op: '&', kids: make([]formula, len(f.kids))}
+	if f.op == '&' {
+		out.op = '|'
+	}
+	for i, k := range f.kids {
+		out.kids[i] = k.not()
+	}
+	return out
+}
+
+func (r *Env) formula() formula { return envFormula(r) }
+
+func (r *PR) formula() formula { return prFormula(r) }
+
+func envFormula(r *Env) formula {
+	var parts []formula
+	if r.tree() {
+		parts = append(parts, treeFormula(r.All, r.Any, r.Not, envFormula))
+	}
+	if !r.tree() || r.leaf() {
+		parts = append(parts, envLeafFormula(r))
+	}
+	return and(parts...)
+}
+
+func envLeafFormula(r *Env) formula {
+	if !r.leaf() {
+		return bottom
+	}
+	var parts []formula
+	if r.StrictEqual != "" {
+		leaf := Env{StrictEqual: r.StrictEqual, Includes: r.Includes, Predicates: r.Predicates}
+		newAtom := atom
+		if r.Includes == "" && r.Predicates.exact() {
+			newAtom = exactAtom
+		}
+		parts = append(parts, newAtom(r.StrictEqual, r.Predicates.candidates(r.Includes), func(val string, ok bool) bool {
+			return leaf.matchLeaf(single(r.StrictEqual, val, ok))
+		}))
+	}
+	return and(append(parts, anySetFormula(r.EqualsAnyOf), equalFormula(r.EqualsMap))...)
+}
+
+func (l *EnvList) formula() formula {
+	parts := make([]formula, len(*l))
+	for i := range *l {
+		parts[i] = envFormula(&(*l)[i])
+	}
+	return and(parts...)
+}
+
+func prFormula(r *PR) formula {
+	var parts []formula
+	if r.tree() {
+		parts = append(parts, treeFormula(r.All, r.Any, r.Not, prFormula))
+	}
+	if !r.tree() || r.leaf() {
+		parts = append(parts, prLeafFormula(r))
+	}
+	return and(parts...)
+}
+
+func prLeafFormula(r *PR) formula {
+	if !r.leaf() {
+		return bottom
+	}
+	if r.StrictEqual == "" {
+		return and(anySetFormula(r.EqualsAnyOf), equalFormula(r.EqualsMap))
+	}
+	leaf := PR{StrictEqual: r.StrictEqual, NotEqual: r.NotEqual, EqualsAnyOf: r.EqualsAnyOf, Predicates: r.Predicates}
+	candidates := append(r.Predicates.candidates(""), r.EqualsAnyOf...)
+	if r.NotEqual != "" {
+		candidates = append(candidates, r.NotEqual)
+	}
+	newAtom := atom
+	if r.Predicates.exact() {
+		newAtom = exactAtom
+	}
+	return and(newAtom(r.StrictEqual, candidates, func(val string, ok bool) bool {
+		return leaf.matchLeaf(single(r.StrictEqual, val, ok))
+	}), equalFormula(r.EqualsMap))
+}
+
+func treeFormula[T any](all, some []T, not *T, f func(*T) formula) formula {
+	var parts []formula
+	for i := range all {
+		parts = append(parts, f(&all[i]))
+	}
+	if len(some) > 0 {
+		alts := make([]formula, len(some))
+		for i := range some {
+			alts[i] = f(&some[i])
+		}
+		parts = append(parts, or(alts...))
+	}
+	if not != nil {
+		parts = append(parts, f(not).not())
+	}
+	return and(parts...)
+}
+
+func anySetFormula(keys []string) formula {
+	if len(keys) == 0 {
+		return top
+	}
+	alts := make([]formula, len(keys))
+	for i, k := range keys {
+		alts[i] = exactAtom(k, nil, func(val string, _ bool) bool { return val != "" })
+	}
+	return or(alts...)
+}
+
+func equalFormula(eq map[string]string) formula {
+	var parts []formula
+	for _, k := range slices.Sorted(maps.Keys(eq)) {
+		want := eq[k]
+		parts = append(parts, exactAtom(k, []string{want}, func(val string, ok bool) bool { return ok && val == want }))
+	}
+	return and(parts...)
+}
+
+func single(key, val string, ok bool) Map {
+	if !ok {
+		return Map{}
+	}
+	return Map{key: val}
+}
+
+// exact reports whether p only tests whether a variable is defined or empty.
+func (p *Predicates) exact() bool {
+	return p.Regex == "" && p.Prefix == "" && p.Suffix == "" && p.EqualFold == "" && !p.Numeric
+}
+
+// candidates suggests values likely to satisfy, or just miss, p.
+// Besides the operands themselves these are other numbers, other cases of
+// an equalFold operand and strings drawn from both ends of a regular
+// expression, so that a rule can also be shown to accept a value other than
+// the obvious one.
+func (p *Predicates) candidates(includes string) []string {
+	out := []string{p.Prefix + includes + p.Suffix, p.EqualFold}
+	if p.EqualFold != "" {
+		out = append(out, strings.ToUpper(p.EqualFold), strings.ToLower(p.EqualFold))
+	}
+	if p.Numeric {
+		out = append(out, "0", "2", "10")
+	}
+	if p.Regex != "" {
+		for _, s := range regexSamples(p.Regex) {
+			out = append(out, s, p.Prefix+s+p.Suffix)
+		}
+	}
+	return out
+}
+
+// regexSamples returns short strings matching pattern, one taking the first
+// choice wherever the pattern offers several and one taking the last.
+func regexSamples(pattern string) []string {
+	re, err := resyntax.Parse(pattern, resyntax.Perl)
+	if err != nil {
+		return nil
+	}
+	re = re.Simplify()
+	var out []string
+	for _, last := range []bool{false, true} {
+		var b strings.Builder
+		if sample(&b, re, last) && !slices.Contains(out, b.String()) {
+			out = append(out, b.String())
+		}
+	}
+	return out
+}
+
+func sample(b *strings.Builder, re *resyntax.Regexp, last bool) bool {
+	switch re.Op {
+	case resyntax.OpNoMatch:
+		return false
+	case resyntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case resyntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		r := re.Rune[0]
+		if last {
+			r = re.Rune[len(re.Rune)-1]
+		}
+		b.WriteRune(r)
+	case resyntax.OpAnyCharNotNL, resyntax.OpAnyChar:
+		b.WriteByte('x')
+	case resyntax.OpCapture, resyntax.OpPlus:
+		return sample(b, re.Sub[0], last)
+	case resyntax.OpRepeat:
+		for range re.Min {
+			if !sample(b, re.Sub[0], last) {
+				return false
+			}
+		}
+	case resyntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !sample(b, sub, last) {
+				return false
+			}
+		}
+	case resyntax.OpAlternate:
+		sub := re.Sub[0]
+		if last {
+			sub = re.Sub[len(re.Sub)-1]
+		}
+		return sample(b, sub, last)
+	}
+	// Empty matches, anchors, word boundaries, OpStar and OpQuest add nothing.
+	return true
+}
+
+// maxTerms bounds the expansion of a formula into alternatives.
+const maxTerms = 4096
+
+// terms expands f into alternatives, each a list of checks that must all
+// hold, and reports whether it kept all of them.
+func terms(f formula) ([][]*check, bool) {
+	if f.check != nil {
+		return [][]*check{{f.check}}, true
+	}
+	complete := true
+	if f.op == '|' {
+		var out [][]*check
+		for _, k := range f.kids {
+			alts, ok := terms(k)
+			out = append(out, alts...)
+			if len(out) > maxTerms {
+				return out[:maxTerms], false
+			}
+			complete = complete && ok
+		}
+		return out, complete
+	}
+	out := [][]*check{nil}
+	for _, k := range f.kids {
+		alts, ok := terms(k)
+		complete = complete && ok
+		next := make([][]*check, 0, min(len(out)*len(alts), maxTerms))
+	expand:
+		for _, a := range out {
+			for _, b := range alts {
+				if len(next) == maxTerms {
+					complete = false
+					break expand
+				}
+				next = append(next, append(slices.Clip(a), b...))
+			}
+		}
+		out = next
+	}
+	return out, complete
+}
+
+func countKeys(checks []*check) int {
+	var keys []string
+	for _, c := range checks {
+		if !slices.Contains(keys, c.key) {
+			keys = append(keys, c.key)
+		}
+	}
+	return len(keys)
+}
+
+// assign picks a value for each variable that passes all of its checks,
+// leaving variables undefined where possible.
+func assign(checks []*check) (map[string]string, bool) {
+	env := map[string]string{}
+	byKey := map[string][]*check{}
+	var order []string
+	for _, c := range checks {
+		if _, ok := byKey[c.key]; !ok {
+			order = append(order, c.key)
+		}
+		byKey[c.key] = append(byKey[c.key], c)
+	}
+	for _, k := range order {
+		cs := byKey[k]
+		holds := func(val string, ok bool) bool {
+			for _, c := range cs {
+				if !c.holds(val, ok) {
+					return false
+				}
+			}
+			return true
+		}
+		if holds("", false) {
+			continue
+		}
+		val, ok := pick(cs, holds)
+		if !ok {
+			return nil, false
+		}
+		env[k] = val
+	}
+	return env, true
+}
+
+func pick(cs []*check, holds func(string, bool) bool) (string, bool) {
+	var pool []string
+	for _, c := range cs {
+		for _, v := range c.candidates {
+			if v != "" && !slices.Contains(pool, v) {
+				pool = append(pool, v)
+			}
+		}
+	}
+	if len(pool) > 1 {
+		pool = append(pool, strings.Join(pool, ""))
+	}
+	pool = append(pool, "true", "1")
+	for _, v := range slices.Clone(pool) {
+		pool = append(pool, v+"x", "x"+v)
+	}
+	// An empty value comes last, as it is rarely what a rule means.
+	pool = append(pool, "")
+	for _, v := range pool {
+		if holds(v, true) {
+			return v, true
+		}
+	}
+	return "", false
+}
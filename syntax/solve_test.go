@@ -0,0 +1,209 @@
+package syntax
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestEnvSatisfy(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		match   map[string]string
+		nomatch map[string]string
+	}{
+		{
+			name:    "presence",
+			expr:    "FOO",
+			match:   map[string]string{"FOO": "true"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "includes",
+			expr:    `NODE contains "/app/.heroku"`,
+			match:   map[string]string{"NODE": "/app/.heroku"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "equality",
+			expr:    `CI == "woodpecker"`,
+			match:   map[string]string{"CI": "woodpecker"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "any picks one",
+			expr:    "JENKINS_URL || HUDSON_URL",
+			match:   map[string]string{"JENKINS_URL": "true"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "not",
+			expr:    "JENKINS_URL && !TAG_NAME",
+			match:   map[string]string{"JENKINS_URL": "true"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "regex",
+			expr:    `REF matches "^release/\\d+$"`,
+			match:   map[string]string{"REF": "release/0"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "undefined",
+			expr:    "FOO is undefined",
+			match:   map[string]string{},
+			nomatch: map[string]string{"FOO": "true"},
+		},
+		{
+			name:    "defined accepts empty",
+			expr:    "!(FOO is defined)",
+			match:   map[string]string{},
+			nomatch: map[string]string{"FOO": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MustParseEnv(tt.expr)
+			got, ok := r.Satisfy()
+			if !ok || !maps.Equal(got, tt.match) || !r.Match(Map(got)) {
+				t.Errorf("Satisfy = %v, %v, want %v", got, ok, tt.match)
+			}
+			got, ok = r.Violate()
+			if !ok || !maps.Equal(got, tt.nomatch) || r.Match(Map(got)) {
+				t.Errorf("Violate = %v, %v, want %v", got, ok, tt.nomatch)
+			}
+		})
+	}
+}
+
+func TestPRSatisfy(t *testing.T) {
+	tests := []struct {
+		name    string
+		pr      PR
+		match   map[string]string
+		nomatch map[string]string
+	}{
+		{
+			name:    "not equal",
+			pr:      PR{StrictEqual: "IS_PR", NotEqual: "false"},
+			match:   map[string]string{"IS_PR": "true"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "not equal true",
+			pr:      PR{StrictEqual: "IS_PR", NotEqual: "true"},
+			match:   map[string]string{"IS_PR": "1"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "accepted values",
+			pr:      PR{StrictEqual: "EVENT", EqualsAnyOf: []string{"pull_request", "pull_request_target"}},
+			match:   map[string]string{"EVENT": "pull_request"},
+			nomatch: map[string]string{},
+		},
+		{
+			name:    "equals map",
+			pr:      PR{EqualsMap: map[string]string{"BUILD_REASON": "PullRequest"}},
+			match:   map[string]string{"BUILD_REASON": "PullRequest"},
+			nomatch: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.pr.Satisfy()
+			if !ok || !maps.Equal(got, tt.match) {
+				t.Errorf("Satisfy = %v, %v, want %v", got, ok, tt.match)
+			}
+			got, ok = tt.pr.Violate()
+			if !ok || !maps.Equal(got, tt.nomatch) {
+				t.Errorf("Violate = %v, %v, want %v", got, ok, tt.nomatch)
+			}
+		})
+	}
+}
+
+func TestSatisfyImpossible(t *testing.T) {
+	for _, r := range []Env{
+		{},
+		MustParseEnv(`FOO && !FOO`),
+		MustParseEnv(`FOO == "a" && FOO == "b"`),
+		MustParseEnv(`FOO is undefined && FOO is defined`),
+	} {
+		if env, ok := r.Satisfy(); ok {
+			t.Errorf("%v: Satisfy = %v, want none", r, env)
+		}
+	}
+
+	always := EnvList{}
+	if env, ok := always.Violate(); ok {
+		t.Errorf("empty EnvList: Violate = %v, want none", env)
+	}
+	if env, ok := always.Satisfy(); !ok || len(env) != 0 {
+		t.Errorf("empty EnvList: Satisfy = %v, %v, want empty", env, ok)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	woodpecker := MustParseEnv(`CI == "woodpecker"`)
+	anyCI := MustParseEnv("CI")
+	gitlab := MustParseEnv("GITLAB_CI")
+
+	env, ok := Solve([]Rule{&woodpecker, &anyCI}, nil)
+	if !ok || !maps.Equal(env, map[string]string{"CI": "woodpecker"}) {
+		t.Errorf("Solve(both) = %v, %v, want CI=woodpecker", env, ok)
+	}
+	if env, ok := Solve([]Rule{&woodpecker}, []Rule{&anyCI}); ok {
+		t.Errorf("Solve(woodpecker without CI) = %v, want none", env)
+	}
+	env, ok = Solve([]Rule{&anyCI}, []Rule{&woodpecker, &gitlab})
+	if !ok || !maps.Equal(env, map[string]string{"CI": "true"}) {
+		t.Errorf("Solve(CI without woodpecker) = %v, %v, want CI=true", env, ok)
+	}
+}
+
+func TestUnsatisfiable(t *testing.T) {
+	woodpecker := MustParseEnv(`CI == "woodpecker"`)
+	anyCI := MustParseEnv("CI")
+	contradiction := MustParseEnv(`FOO is undefined && (FOO || FOO == "")`)
+	numeric := MustParseEnv("FOO is numeric")
+	prefix := MustParseEnv(`FOO startsWith "1"`)
+
+	tests := []struct {
+		name           string
+		match, nomatch []Rule
+		want           bool
+	}{
+		{"subsumed", []Rule{&woodpecker}, []Rule{&anyCI}, true},
+		{"contradiction", []Rule{&contradiction}, nil, true},
+		{"satisfiable", []Rule{&anyCI}, []Rule{&woodpecker}, false},
+		// FOO=2 is numeric without the prefix.
+		{"value patterns", []Rule{&numeric}, []Rule{&prefix}, false},
+		// No proof is claimed for patterns, even where there is nothing to
+		// find.
+		{"value patterns contradiction", []Rule{&prefix}, []Rule{&prefix}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unsatisfiable(tt.match, tt.nomatch); got != tt.want {
+				t.Errorf("Unsatisfiable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfyOtherValues(t *testing.T) {
+	for _, expr := range []string{
+		`A is numeric && !(A startsWith "1")`,
+		`A equalFold "abc" && A != "abc"`,
+		`A matches "^[0-9]{3}$" && A != "000"`,
+		`A matches "^(dev|prod)$" && A != "dev"`,
+	} {
+		r := MustParseEnv(expr)
+		env, ok := r.Satisfy()
+		if !ok || !r.Match(Map(env)) {
+			t.Errorf("%s: Satisfy = %v, %v, want a witness", expr, env, ok)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package syntax
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DecodeError reports where strict decoding failed. Path locates the value
+// within the decoded document, as in "any[1]" or "all[0].not".
+type DecodeError struct {
+	Path string
+	Msg  string
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return e.Path + ": " + e.Msg
+}
+
+// JoinPath appends elem, an object key or an "[i]" index, to path.
+func JoinPath(path, elem string) string {
+	switch {
+	case path == "":
+		return elem
+	case elem == "":
+		return path
+	case elem[0] == '[':
+		return path + elem
+	}
+	return path + "." + elem
+}
+
+// UnmarshalStrict decodes data into v, which must be a *Env, *EnvList, *PR or
+// *Extract.
+// Unlike json.Unmarshal it rejects unknown operators, wrongly typed values,
+// empty rules and anything Validate reports, with a *DecodeError. An object
+// key that is not an operator of the rule but looks like one, such as
+// "include", "Env" or "ne" on an Env, is rejected rather than read as a
+// variable.
+func UnmarshalStrict(data []byte, v any) error {
+	if !json.Valid(data) {
+		return &DecodeError{Msg: "invalid JSON"}
+	}
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return &DecodeError{Msg: err.Error()}
+	}
+
+	var err error
+	var validate func() error
+	switch v := v.(type) {
+	case *Env:
+		err = checkRule("", raw, false)
+		validate = v.Validate
+	case *PR:
+		err = checkRule("", raw, true)
+		validate = v.Validate
+	case *EnvList:
+		err = checkList("", raw)
+		validate = v.Validate
+	case *Extract:
+		err = checkExtract("", raw)
+		validate = func() error { return nil }
+	default:
+		return fmt.Errorf("syntax: cannot strictly decode into %T", v)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return &DecodeError{Msg: err.Error()}
+	}
+	if err := validate(); err != nil {
+		return firstDecodeError(err)
+	}
+	return nil
+}
+
+func firstDecodeError(err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return firstDecodeError(joined.Unwrap()[0])
+	}
+	if p, ok := err.(*pathError); ok {
+		return &DecodeError{Path: p.path, Msg: p.err.Error()}
+	}
+	return &DecodeError{Msg: err.Error()}
+}
+
+func checkList(path string, raw any) error {
+	switch raw := raw.(type) {
+	case string, map[string]any:
+		return checkRule(path, raw, false)
+	case []any:
+		if len(raw) == 0 {
+			return &DecodeError{path, "empty rule list"}
+		}
+		for i, r := range raw {
+			if err := checkRule(JoinPath(path, "["+strconv.Itoa(i)+"]"), r, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &DecodeError{path, "expected string, object or array"}
+}
+
+var (
+	stringOperators = []string{"env", "regex", "prefix", "suffix", "equalFold"}
+	boolOperators   = []string{"numeric", "nonEmpty", "defined", "unset"}
+	operators       = slices.Concat(stringOperators, boolOperators, []string{"includes", "ne", "any", "all", "not"})
+	typos           = []string{"include", "contains", "regexp", "startsWith", "endsWith", "undefined", "notEqual"}
+)
+
+func checkRule(path string, raw any, pr bool) error {
+	switch raw := raw.(type) {
+	case string:
+		if raw == "" {
+			return &DecodeError{path, "empty rule"}
+		}
+		return nil
+	case map[string]any:
+		if len(raw) == 0 {
+			return &DecodeError{path, "empty rule"}
+		}
+		keys := make([]string, 0, len(raw))
+		for k := range raw {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			if err := checkMember(JoinPath(path, k), k, raw[k], pr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &DecodeError{path, "expected string or object"}
+}
+
+func checkMember(path, key string, v any, pr bool) error {
+	switch {
+	case slices.Contains(stringOperators, key),
+		key == "includes" && !pr,
+		key == "ne" && pr:
+		s, ok := v.(string)
+		if !ok {
+			return &DecodeError{path, "expected string"}
+		}
+		if s == "" {
+			return &DecodeError{path, "expected non-empty string"}
+		}
+		if key == "regex" {
+			if _, err := compile(s); err != nil {
+				return &DecodeError{path, err.Error()}
+			}
+		}
+		return nil
+
+	case slices.Contains(boolOperators, key):
+		if _, ok := v.(bool); !ok {
+			return &DecodeError{path, "expected boolean"}
+		}
+		return nil
+
+	case key == "any":
+		elems, ok := v.([]any)
+		if !ok {
+			return &DecodeError{path, "expected array"}
+		}
+		if len(elems) == 0 {
+			return &DecodeError{path, "empty rule list"}
+		}
+		for i, e := range elems {
+			elemPath := JoinPath(path, "["+strconv.Itoa(i)+"]")
+			switch e.(type) {
+			case string, map[string]any:
+				if err := checkRule(elemPath, e, pr); err != nil {
+					return err
+				}
+			default:
+				return &DecodeError{elemPath, "expected string"}
+			}
+		}
+		return nil
+
+	case key == "all":
+		elems, ok := v.([]any)
+		if !ok {
+			return &DecodeError{path, "expected array"}
+		}
+		if len(elems) == 0 {
+			return &DecodeError{path, "empty rule list"}
+		}
+		for i, e := range elems {
+			if err := checkRule(JoinPath(path, "["+strconv.Itoa(i)+"]"), e, pr); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case key == "not":
+		return checkRule(path, v, pr)
+
+	case key == "" || misspelled(key):
+		return &DecodeError{path, "unknown key"}
+	}
+
+	if _, ok := v.(string); !ok {
+		return &DecodeError{path, "expected string"}
+	}
+	return nil
+}
+
+// misspelled reports whether key looks like an operator rather than a
+// variable: an operator written in another case, unless all in upper case as
+// variables usually are, or a common misspelling of one. Lowercase variables
+// such as net or bamboo_buildKey are left alone.
+func misspelled(key string) bool {
+	if slices.Contains(typos, key) {
+		return true
+	}
+	if strings.ToUpper(key) == key {
+		return false
+	}
+	return slices.ContainsFunc(operators, func(op string) bool { return strings.EqualFold(key, op) })
+}
+
+func checkExtract(path string, raw any) error {
+	sources, ok := raw.([]any)
+	if !ok {
+		return &DecodeError{path, "expected array"}
+	}
+	for i, src := range sources {
+		srcPath := JoinPath(path, "["+strconv.Itoa(i)+"]")
+		obj, ok := src.(map[string]any)
+		if !ok {
+			return &DecodeError{srcPath, "expected object"}
+		}
+		if obj["env"] == nil && obj["template"] == nil {
+			return &DecodeError{srcPath, "missing env or template"}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			keyPath := JoinPath(srcPath, k)
+			switch k {
+			case "env", "template", "trimPrefix":
+				if _, ok := obj[k].(string); !ok {
+					return &DecodeError{keyPath, "expected string"}
+				}
+			case "if":
+				if err := checkList(keyPath, obj[k]); err != nil {
+					return err
+				}
+			default:
+				return &DecodeError{keyPath, "unknown key"}
+			}
+		}
+	}
+	return nil
+}
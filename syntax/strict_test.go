@@ -0,0 +1,60 @@
+package syntax
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		into any
+		want any // decoded value, or the *DecodeError expected
+	}{
+		{"env", `{"env":"A","includes":"x"}`, new(Env), &Env{StrictEqual: "A", Includes: "x"}},
+		{"list", `["A",{"any":["B","C"]}]`, new(EnvList), &EnvList{{StrictEqual: "A"}, {EqualsAnyOf: []string{"B", "C"}}}},
+		{"pr", `{"env":"A","ne":"false"}`, new(PR), &PR{StrictEqual: "A", NotEqual: "false"}},
+		{"extract", `[{"env":"A","if":"B"}]`, new(Extract), &Extract{{Env: "A", If: EnvList{{StrictEqual: "B"}}}}},
+		{"lowercase variable", `{"bamboo_buildKey":"x","ENV":"y"}`, new(Env), &Env{EqualsMap: map[string]string{"bamboo_buildKey": "x", "ENV": "y"}}},
+		{"variable near an operator", `{"net":"1","me":"2","ant":"3","nor":"4"}`, new(PR), &PR{EqualsMap: map[string]string{"net": "1", "me": "2", "ant": "3", "nor": "4"}}},
+
+		{"invalid json", `{"env":`, new(Env), &DecodeError{"", "invalid JSON"}},
+		{"wrong type", `1`, new(Env), &DecodeError{"", "expected string or object"}},
+		{"empty string", `""`, new(PR), &DecodeError{"", "empty rule"}},
+		{"empty object", `{}`, new(Env), &DecodeError{"", "empty rule"}},
+		{"empty list", `[]`, new(EnvList), &DecodeError{"", "empty rule list"}},
+		{"unknown operator", `{"env":"A","include":"x"}`, new(Env), &DecodeError{"include", "unknown key"}},
+		{"capitalized operator", `{"Env":"A"}`, new(PR), &DecodeError{"Env", "unknown key"}},
+		{"misspelled operator", `{"env":"A","startsWith":"x"}`, new(Env), &DecodeError{"startsWith", "unknown key"}},
+		{"ne on env", `{"env":"A","ne":"x"}`, new(Env), &DecodeError{"ne", "unknown key"}},
+		{"includes on pr", `{"env":"A","includes":"x"}`, new(PR), &DecodeError{"includes", "unknown key"}},
+		{"non-string value", `{"A":1}`, new(Env), &DecodeError{"A", "expected string"}},
+		{"null value", `{"A":null}`, new(PR), &DecodeError{"A", "expected string"}},
+		{"bool operator", `{"env":"A","numeric":"yes"}`, new(Env), &DecodeError{"numeric", "expected boolean"}},
+		{"any element", `{"any":["A",1]}`, new(PR), &DecodeError{"any[1]", "expected string"}},
+		{"nested", `[{"all":["A",{"not":{"env":""}}]}]`, new(EnvList), &DecodeError{"[0].all[1].not.env", "expected non-empty string"}},
+		{"regex", `{"env":"A","regex":"("}`, new(Env), &DecodeError{"regex", "error parsing regexp: missing closing ): `(`"}},
+		{"validate", `{"any":["A"],"includes":"x"}`, new(Env), &DecodeError{"", "includes is ignored without env"}},
+		{"extract key", `[{"env":"A","trim":"x"}]`, new(Extract), &DecodeError{"[0].trim", "unknown key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UnmarshalStrict([]byte(tt.data), tt.into)
+			if want, ok := tt.want.(*DecodeError); ok {
+				var got *DecodeError
+				if !errors.As(err, &got) || *got != *want {
+					t.Errorf("error = %v, want %v", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.into, tt.want) {
+				t.Errorf("got %#v, want %#v", tt.into, tt.want)
+			}
+		})
+	}
+}
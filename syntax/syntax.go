@@ -1,6 +1,8 @@
 package syntax
 
 import (
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -11,6 +13,10 @@ type Env struct {
 	Includes    string
 	EqualsAnyOf []string
 	EqualsMap   map[string]string
+	Predicates
+	All []Env
+	Any []Env
+	Not *Env
 }
 
 type PR struct {
@@ -18,36 +24,38 @@ type PR struct {
 	NotEqual    string
 	EqualsAnyOf []string
 	EqualsMap   map[string]string
+	Predicates
+	All []PR
+	Any []PR
+	Not *PR
 }
 
-func (r *Env) Match(env map[string]string) bool {
-	switch {
-	case r.StrictEqual != "" && r.Includes == "":
-		return env[r.StrictEqual] != ""
-
-	case r.StrictEqual != "" && r.Includes != "":
-		return strings.Contains(env[r.StrictEqual], r.Includes)
+func (r *Env) Match(env Environment) bool {
+	if r.tree() {
+		return matchTree(r.All, r.Any, r.Not, env) && (!r.leaf() || r.matchLeaf(env))
+	}
+	return r.matchLeaf(env)
+}
 
-	case len(r.EqualsAnyOf) > 0:
-		for _, k := range r.EqualsAnyOf {
-			if env[k] != "" {
-				return true
-			}
-		}
+// matchLeaf requires every populated field to hold. A leaf with no fields
+// never matches.
+func (r *Env) matchLeaf(env Environment) bool {
+	if !r.leaf() {
 		return false
-
-	case len(r.EqualsMap) > 0:
-		for k, v := range r.EqualsMap {
-			if env[k] != v {
-				return false
-			}
+	}
+	if r.StrictEqual != "" {
+		val, ok := env.Lookup(r.StrictEqual)
+		if r.Predicates.empty() && val == "" || !r.Predicates.empty() && !r.Predicates.match(val, ok) {
+			return false
+		}
+		if !strings.Contains(val, r.Includes) {
+			return false
 		}
-		return true
 	}
-	return false
+	return anySet(r.EqualsAnyOf, env) && allEqual(r.EqualsMap, env)
 }
 
-func (l *EnvList) Match(env map[string]string) bool {
+func (l *EnvList) Match(env Environment) bool {
 	for _, rule := range *l {
 		if !rule.Match(env) {
 			return false
@@ -56,38 +64,97 @@ func (l *EnvList) Match(env map[string]string) bool {
 	return true
 }
 
-func (r *PR) Match(env map[string]string) bool {
+func (r *PR) Match(env Environment) bool {
+	if r.tree() {
+		return matchTree(r.All, r.Any, r.Not, env) && (!r.leaf() || r.matchLeaf(env))
+	}
+	return r.matchLeaf(env)
+}
+
+// matchLeaf requires every populated field to hold. With StrictEqual set,
+// EqualsAnyOf lists accepted values of that variable rather than variables.
+func (r *PR) matchLeaf(env Environment) bool {
+	if !r.leaf() {
+		return false
+	}
+	if r.StrictEqual == "" {
+		return anySet(r.EqualsAnyOf, env) && allEqual(r.EqualsMap, env)
+	}
+
+	val, ok := env.Lookup(r.StrictEqual)
+	plain := len(r.EqualsAnyOf) == 0 && r.NotEqual == "" && r.Predicates.empty()
 	switch {
-	case r.StrictEqual != "" && len(r.EqualsAnyOf) == 0 && r.NotEqual == "":
-		return env[r.StrictEqual] != ""
+	case plain && val == "":
+		return false
+	case len(r.EqualsAnyOf) > 0 && (!ok || !slices.Contains(r.EqualsAnyOf, val)):
+		return false
+	case r.NotEqual != "" && (!ok || val == r.NotEqual):
+		return false
+	case !r.Predicates.empty() && !r.Predicates.match(val, ok):
+		return false
+	}
+	return allEqual(r.EqualsMap, env)
+}
 
-	case r.StrictEqual != "" && len(r.EqualsAnyOf) > 0:
-		for _, v := range r.EqualsAnyOf {
-			if env[r.StrictEqual] == v {
-				return true
-			}
+func anySet(keys []string, env Environment) bool {
+	if len(keys) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(keys, func(k string) bool { return get(env, k) != "" })
+}
+
+// allEqual reports whether every variable of eq is set to its value. An
+// unset variable never equals anything, not even "".
+func allEqual(eq map[string]string, env Environment) bool {
+	for k, want := range eq {
+		if val, ok := env.Lookup(k); !ok || val != want {
+			return false
 		}
-		return false
+	}
+	return true
+}
 
-	case r.StrictEqual != "" && r.NotEqual != "":
-		val, ok := env[r.StrictEqual]
-		return ok && val != r.NotEqual
+// Keys returns the variables Match reads, in evaluation order.
+func (r *Env) Keys() []string {
+	return appendTreeKeys(r.leafKeys(), r.All, r.Any, r.Not)
+}
 
-	case len(r.EqualsAnyOf) > 0:
-		for _, k := range r.EqualsAnyOf {
-			if env[k] != "" {
-				return true
+func (r *Env) leafKeys() []string {
+	var keys []string
+	if r.StrictEqual != "" {
+		keys = append(keys, r.StrictEqual)
+	}
+	return appendKeys(keys, r.EqualsAnyOf, r.EqualsMap)
+}
+
+func (l *EnvList) Keys() []string {
+	var out []string
+	for i := range *l {
+		for _, k := range (*l)[i].Keys() {
+			if !slices.Contains(out, k) {
+				out = append(out, k)
 			}
 		}
-		return false
+	}
+	return out
+}
 
-	case len(r.EqualsMap) > 0:
-		for k, v := range r.EqualsMap {
-			if env[k] != v {
-				return false
-			}
+func (r *PR) Keys() []string {
+	return appendTreeKeys(r.leafKeys(), r.All, r.Any, r.Not)
+}
+
+func (r *PR) leafKeys() []string {
+	if r.StrictEqual != "" {
+		return appendKeys([]string{r.StrictEqual}, nil, r.EqualsMap)
+	}
+	return appendKeys(nil, r.EqualsAnyOf, r.EqualsMap)
+}
+
+func appendKeys(keys, anyOf []string, eq map[string]string) []string {
+	for _, k := range append(slices.Clone(anyOf), slices.Sorted(maps.Keys(eq))...) {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
 		}
-		return true
 	}
-	return false
+	return keys
 }
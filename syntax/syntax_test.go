@@ -1,6 +1,7 @@
 package syntax
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -69,7 +70,7 @@ func TestEnvMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.env.Match(tt.data)
+			got := tt.env.Match(Map(tt.data))
 			if got != tt.want {
 				t.Errorf("Match() = %v, want %v", got, tt.want)
 			}
@@ -102,7 +103,7 @@ func TestEnvListMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := list.Match(tt.data)
+			got := list.Match(Map(tt.data))
 			if got != tt.want {
 				t.Errorf("EnvList.Match() = %v, want %v", got, tt.want)
 			}
@@ -175,10 +176,34 @@ func TestPRMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.pr.Match(tt.data)
+			got := tt.pr.Match(Map(tt.data))
 			if got != tt.want {
 				t.Errorf("PR.Match() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"Env StrictEqual", (&Env{StrictEqual: "FOO", Includes: "x"}).Keys(), []string{"FOO"}},
+		{"Env EqualsAnyOf", (&Env{EqualsAnyOf: []string{"A", "B"}}).Keys(), []string{"A", "B"}},
+		{"Env EqualsMap", (&Env{EqualsMap: map[string]string{"B": "1", "A": "2"}}).Keys(), []string{"A", "B"}},
+		{"Env empty", (&Env{}).Keys(), nil},
+		{"EnvList", (&EnvList{{StrictEqual: "A"}, {EqualsAnyOf: []string{"A", "B"}}}).Keys(), []string{"A", "B"}},
+		{"PR values are not keys", (&PR{StrictEqual: "EV", EqualsAnyOf: []string{"x", "y"}}).Keys(), []string{"EV"}},
+		{"PR EqualsAnyOf", (&PR{EqualsAnyOf: []string{"A", "B"}}).Keys(), []string{"A", "B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package syntax
+
+import "slices"
+
+// rule is implemented by *Env and *PR, which double as nodes of a rule tree:
+// All, Any and Not combine child rules, and the remaining fields form a leaf.
+// A node with both children and leaf fields needs both to match.
+type rule interface {
+	Match(env Environment) bool
+	Keys() []string
+}
+
+func (r *Env) tree() bool {
+	return len(r.All) > 0 || len(r.Any) > 0 || r.Not != nil
+}
+
+func (r *Env) leaf() bool {
+	return r.StrictEqual != "" || len(r.EqualsAnyOf) > 0 || len(r.EqualsMap) > 0
+}
+
+func (r *PR) tree() bool {
+	return len(r.All) > 0 || len(r.Any) > 0 || r.Not != nil
+}
+
+func (r *PR) leaf() bool {
+	return r.StrictEqual != "" || len(r.EqualsAnyOf) > 0 || len(r.EqualsMap) > 0
+}
+
+func matchTree[T any, P interface {
+	*T
+	rule
+}](all, some []T, not P, env Environment) bool {
+	for i := range all {
+		if !P(&all[i]).Match(env) {
+			return false
+		}
+	}
+	if len(some) > 0 && !anyMatch[T, P](some, env) {
+		return false
+	}
+	return not == nil || !not.Match(env)
+}
+
+func anyMatch[T any, P interface {
+	*T
+	rule
+}](some []T, env Environment) bool {
+	for i := range some {
+		if P(&some[i]).Match(env) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendTreeKeys[T any, P interface {
+	*T
+	rule
+}](keys []string, all, some []T, not P) []string {
+	add := func(r P) {
+		for _, k := range r.Keys() {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	for i := range all {
+		add(&all[i])
+	}
+	for i := range some {
+		add(&some[i])
+	}
+	if not != nil {
+		add(not)
+	}
+	return keys
+}
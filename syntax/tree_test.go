@@ -0,0 +1,163 @@
+package syntax
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTreeMatch(t *testing.T) {
+	// Jenkins, but not a tag build: (JENKINS_URL or HUDSON_URL) and not TAG_NAME.
+	jenkins := Env{
+		Any: []Env{{StrictEqual: "JENKINS_URL"}, {StrictEqual: "HUDSON_URL"}},
+		Not: &Env{StrictEqual: "TAG_NAME"},
+	}
+	tests := []struct {
+		name string
+		rule Env
+		data map[string]string
+		want bool
+	}{
+		{"any first", jenkins, map[string]string{"JENKINS_URL": "x"}, true},
+		{"any second", jenkins, map[string]string{"HUDSON_URL": "x"}, true},
+		{"any none", jenkins, map[string]string{}, false},
+		{"not", jenkins, map[string]string{"JENKINS_URL": "x", "TAG_NAME": "v1"}, false},
+		{"all", Env{All: []Env{{StrictEqual: "A"}, {StrictEqual: "B"}}}, map[string]string{"A": "1", "B": "1"}, true},
+		{"all missing", Env{All: []Env{{StrictEqual: "A"}, {StrictEqual: "B"}}}, map[string]string{"A": "1"}, false},
+		{"leaf and tree", Env{StrictEqual: "A", Not: &Env{StrictEqual: "B"}}, map[string]string{"B": "1"}, false},
+		{"nested", Env{Not: &Env{Any: []Env{{StrictEqual: "A"}, {StrictEqual: "B"}}}}, map[string]string{"C": "1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Match(Map(tt.data)); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	pr := PR{StrictEqual: "CHANGE_ID", Not: &PR{StrictEqual: "CHANGE_ID", EqualsAnyOf: []string{"0"}}}
+	if !pr.Match(Map{"CHANGE_ID": "12"}) || pr.Match(Map{"CHANGE_ID": "0"}) {
+		t.Error("PR tree did not match as expected")
+	}
+}
+
+func TestTreeKeys(t *testing.T) {
+	r := Env{
+		StrictEqual: "A",
+		All:         []Env{{StrictEqual: "B"}, {StrictEqual: "A"}},
+		Not:         &Env{EqualsAnyOf: []string{"C", "D"}},
+	}
+	if got, want := r.Keys(), []string{"A", "B", "C", "D"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Env
+	}{
+		{
+			data: `{"any":["A","B"]}`,
+			want: Env{EqualsAnyOf: []string{"A", "B"}},
+		},
+		{
+			data: `{"any":["A",{"env":"B","prefix":"x"}],"not":"C"}`,
+			want: Env{
+				Any: []Env{{StrictEqual: "A"}, {StrictEqual: "B", Predicates: Predicates{Prefix: "x"}}},
+				Not: &Env{StrictEqual: "C"},
+			},
+		},
+		{
+			data: `{"env":"A","all":["B",{"not":{"any":["C","D"]}}]}`,
+			want: Env{
+				StrictEqual: "A",
+				All:         []Env{{StrictEqual: "B"}, {Not: &Env{EqualsAnyOf: []string{"C", "D"}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got Env
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			out, err := json.Marshal(got)
+			if err != nil || string(out) != tt.data {
+				t.Errorf("Marshal = %s, %v", out, err)
+			}
+		})
+	}
+
+	var pr PR
+	if err := json.Unmarshal([]byte(`{"env":"PR","not":{"env":"PR","any":["false"]}}`), &pr); err != nil {
+		t.Fatal(err)
+	}
+	want := PR{StrictEqual: "PR", Not: &PR{StrictEqual: "PR", EqualsAnyOf: []string{"false"}}}
+	if !reflect.DeepEqual(pr, want) {
+		t.Errorf("PR = %+v, want %+v", pr, want)
+	}
+}
+
+func TestTreeJSONAnyOfWithAny(t *testing.T) {
+	env := Env{
+		EqualsAnyOf: []string{"A"},
+		Any:         []Env{{StrictEqual: "B"}, {StrictEqual: "C", Includes: "x"}},
+	}
+	pr := PR{
+		StrictEqual: "EVENT",
+		EqualsAnyOf: []string{"pr", "mr"},
+		Any:         []PR{{StrictEqual: "B"}, {StrictEqual: "C", NotEqual: "false"}},
+		EqualsMap:   map[string]string{"A": "x"},
+	}
+	prNames := PR{StrictEqual: "EVENT", Any: []PR{{StrictEqual: "A"}, {StrictEqual: "B"}}}
+	tests := []struct {
+		name string
+		rule interface{ Match(Environment) bool }
+		got  interface{ Match(Environment) bool }
+		want string
+	}{
+		{"env", &env, new(Env), `{"all":[{"any":["A"]}],"any":["B",{"env":"C","includes":"x"}]}`},
+		{"pr", &pr, new(PR), `{"all":[{"env":"EVENT","any":["pr","mr"]}],"any":["B",{"env":"C","ne":"false"}],"A":"x"}`},
+		{"pr env with names", &prNames, new(PR), `{"env":"EVENT","all":[{"any":["A","B"]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.rule)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal = %s, %v, want %s", data, err, tt.want)
+			}
+			if err := json.Unmarshal(data, tt.got); err != nil {
+				t.Fatal(err)
+			}
+			for _, env := range environments([]string{"A", "B", "C", "EVENT"}, []string{"", "x", "pr", "false"}) {
+				if got, want := tt.got.Match(env), tt.rule.Match(env); got != want {
+					t.Errorf("decoded rule matches %v: %v, original: %v", env, got, want)
+				}
+			}
+		})
+	}
+}
+
+// environments returns every assignment of values, or no value, to keys.
+func environments(keys, values []string) []Map {
+	out := []Map{{}}
+	for _, k := range keys {
+		next := make([]Map, 0, len(out)*(len(values)+1))
+		for _, m := range out {
+			next = append(next, m)
+			for _, v := range values {
+				m2 := maps.Clone(m)
+				m2[k] = v
+				next = append(next, m2)
+			}
+		}
+		out = next
+	}
+	return out
+}
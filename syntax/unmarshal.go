@@ -6,6 +6,10 @@ package syntax
 import "encoding/json"
 
 func (e *Env) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
 		e.StrictEqual = s
@@ -30,14 +34,27 @@ func (e *Env) UnmarshalJSON(data []byte) error {
 				return err
 			}
 		case "any":
-			if err := json.Unmarshal(v, &e.EqualsAnyOf); err != nil {
+			if err := unmarshalAny(v, &e.EqualsAnyOf, &e.Any); err != nil {
+				return err
+			}
+		case "all":
+			if err := json.Unmarshal(v, &e.All); err != nil {
+				return err
+			}
+		case "not":
+			if err := json.Unmarshal(v, &e.Not); err != nil {
+				return err
+			}
+		case "regex", "prefix", "suffix", "equalFold", "numeric", "nonEmpty", "defined", "unset":
+			if err := json.Unmarshal(v, e.Predicates.field(k)); err != nil {
 				return err
 			}
 		default:
 			var val string
-			if err := json.Unmarshal(v, &val); err == nil {
-				e.EqualsMap[k] = val
+			if err := json.Unmarshal(v, &val); err != nil {
+				return err
 			}
+			e.EqualsMap[k] = val
 		}
 	}
 
@@ -45,10 +62,13 @@ func (e *Env) UnmarshalJSON(data []byte) error {
 		e.EqualsMap = nil
 	}
 
-	return nil
+	return e.Predicates.validate()
 }
 
 func (l *EnvList) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
 
 	if len(data) > 0 && data[0] == '[' {
 		return json.Unmarshal(data, (*[]Env)(l))
@@ -63,6 +83,10 @@ func (l *EnvList) UnmarshalJSON(data []byte) error {
 }
 
 func (p *PR) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
 		p.StrictEqual = s
@@ -83,18 +107,31 @@ func (p *PR) UnmarshalJSON(data []byte) error {
 				return err
 			}
 		case "any":
-			if err := json.Unmarshal(v, &p.EqualsAnyOf); err != nil {
+			if err := unmarshalAny(v, &p.EqualsAnyOf, &p.Any); err != nil {
+				return err
+			}
+		case "all":
+			if err := json.Unmarshal(v, &p.All); err != nil {
+				return err
+			}
+		case "not":
+			if err := json.Unmarshal(v, &p.Not); err != nil {
 				return err
 			}
 		case "ne":
 			if err := json.Unmarshal(v, &p.NotEqual); err != nil {
 				return err
 			}
+		case "regex", "prefix", "suffix", "equalFold", "numeric", "nonEmpty", "defined", "unset":
+			if err := json.Unmarshal(v, p.Predicates.field(k)); err != nil {
+				return err
+			}
 		default:
 			var val string
-			if err := json.Unmarshal(v, &val); err == nil {
-				p.EqualsMap[k] = val
+			if err := json.Unmarshal(v, &val); err != nil {
+				return err
 			}
+			p.EqualsMap[k] = val
 		}
 	}
 
@@ -102,5 +139,15 @@ func (p *PR) UnmarshalJSON(data []byte) error {
 		p.EqualsMap = nil
 	}
 
-	return nil
+	return p.Predicates.validate()
+}
+
+// unmarshalAny decodes "any" into keys when it only lists variable names, as
+// in ci-info, and into subrules otherwise.
+func unmarshalAny[T any](data []byte, keys *[]string, rules *[]T) error {
+	if err := json.Unmarshal(data, keys); err == nil {
+		return nil
+	}
+	*keys = nil
+	return json.Unmarshal(data, rules)
 }
@@ -17,6 +17,10 @@ var (
 func (e *Env) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	switch dec.PeekKind() {
 
+	case 'n': // null leaves the rule unchanged, as in encoding/json
+		_, err := dec.ReadToken()
+		return err
+
 	case '"': // string
 		return json.UnmarshalDecode(dec, &e.StrictEqual)
 
@@ -43,7 +47,19 @@ func (e *Env) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 					return err
 				}
 			case "any":
-				if err := json.UnmarshalDecode(dec, &e.EqualsAnyOf); err != nil {
+				if err := unmarshalAny(dec, &e.EqualsAnyOf, &e.Any); err != nil {
+					return err
+				}
+			case "all":
+				if err := json.UnmarshalDecode(dec, &e.All); err != nil {
+					return err
+				}
+			case "not":
+				if err := json.UnmarshalDecode(dec, &e.Not); err != nil {
+					return err
+				}
+			case "regex", "prefix", "suffix", "equalFold", "numeric", "nonEmpty", "defined", "unset":
+				if err := json.UnmarshalDecode(dec, e.Predicates.field(key)); err != nil {
 					return err
 				}
 			default:
@@ -63,7 +79,7 @@ func (e *Env) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 		}
 
 		e.EqualsMap = eq
-		return nil
+		return e.Predicates.validate()
 	}
 
 	return ErrInvalidEnv
@@ -72,12 +88,16 @@ func (e *Env) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 func (l *EnvList) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	switch dec.PeekKind() {
 
+	case 'n':
+		_, err := dec.ReadToken()
+		return err
+
 	case '[':
 		if _, err := dec.ReadToken(); err != nil { // [
 			return err
 		}
 
-		var list []Env
+		list := []Env{}
 		for dec.PeekKind() != ']' {
 			var e Env
 			if err := e.UnmarshalJSONFrom(dec); err != nil {
@@ -106,6 +126,10 @@ func (l *EnvList) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 func (p *PR) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	switch dec.PeekKind() {
 
+	case 'n': // null leaves the rule unchanged, as in encoding/json
+		_, err := dec.ReadToken()
+		return err
+
 	case '"': // string
 		return json.UnmarshalDecode(dec, &p.StrictEqual)
 
@@ -128,13 +152,25 @@ func (p *PR) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 					return err
 				}
 			case "any":
-				if err := json.UnmarshalDecode(dec, &p.EqualsAnyOf); err != nil {
+				if err := unmarshalAny(dec, &p.EqualsAnyOf, &p.Any); err != nil {
+					return err
+				}
+			case "all":
+				if err := json.UnmarshalDecode(dec, &p.All); err != nil {
+					return err
+				}
+			case "not":
+				if err := json.UnmarshalDecode(dec, &p.Not); err != nil {
 					return err
 				}
 			case "ne":
 				if err := json.UnmarshalDecode(dec, &p.NotEqual); err != nil {
 					return err
 				}
+			case "regex", "prefix", "suffix", "equalFold", "numeric", "nonEmpty", "defined", "unset":
+				if err := json.UnmarshalDecode(dec, p.Predicates.field(key)); err != nil {
+					return err
+				}
 			default:
 				if eq == nil {
 					eq = make(map[string]string)
@@ -152,8 +188,22 @@ func (p *PR) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 		}
 
 		p.EqualsMap = eq
-		return nil
+		return p.Predicates.validate()
 	}
 
 	return ErrInvalidPR
 }
+
+// unmarshalAny decodes "any" into keys when it only lists variable names, as
+// in ci-info, and into subrules otherwise.
+func unmarshalAny[T any](dec *jsontext.Decoder, keys *[]string, rules *[]T) error {
+	raw, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, keys); err == nil {
+		return nil
+	}
+	*keys = nil
+	return json.Unmarshal(raw, rules)
+}
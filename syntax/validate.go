@@ -0,0 +1,126 @@
+package syntax
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Validate reports fields that can never take part in matching, rules that
+// can never match, and invalid regular expressions. Children of All, Any and
+// Not are checked too, and errors name the path to the offending rule.
+func (r *Env) Validate() error {
+	var errs []error
+	if !r.leaf() && !r.tree() {
+		errs = append(errs, errors.New("rule is empty and never matches"))
+	}
+	if r.StrictEqual == "" {
+		if r.Includes != "" {
+			errs = append(errs, errors.New("includes is ignored without env"))
+		}
+		if !r.Predicates.empty() {
+			errs = append(errs, errors.New("predicates are ignored without env"))
+		}
+	}
+	if r.Unset && r.Includes != "" {
+		errs = append(errs, errors.New("unset contradicts includes"))
+	}
+	if err := checkEquals(r.EqualsMap, false); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, r.Predicates.check()...)
+	errs = append(errs, validateTree(r.All, r.Any, r.Not)...)
+	return errors.Join(errs...)
+}
+
+func (l *EnvList) Validate() error {
+	var errs []error
+	for i := range *l {
+		if err := (*l)[i].Validate(); err != nil {
+			errs = append(errs, prefix("["+strconv.Itoa(i)+"]", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *PR) Validate() error {
+	var errs []error
+	if !r.leaf() && !r.tree() {
+		errs = append(errs, errors.New("rule is empty and never matches"))
+	}
+	if r.StrictEqual == "" {
+		if r.NotEqual != "" {
+			errs = append(errs, errors.New("ne is ignored without env"))
+		}
+		if !r.Predicates.empty() {
+			errs = append(errs, errors.New("predicates are ignored without env"))
+		}
+	}
+	if r.Unset && (r.NotEqual != "" || len(r.EqualsAnyOf) > 0) {
+		errs = append(errs, errors.New("unset contradicts ne and any"))
+	}
+	if err := checkEquals(r.EqualsMap, true); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, r.Predicates.check()...)
+	errs = append(errs, validateTree(r.All, r.Any, r.Not)...)
+	return errors.Join(errs...)
+}
+
+func (p *Predicates) check() []error {
+	var errs []error
+	if p.Unset && (p.Regex != "" || p.Prefix != "" || p.Suffix != "" || p.EqualFold != "" || p.Numeric || p.NonEmpty || p.Defined) {
+		errs = append(errs, errors.New("unset contradicts the other predicates"))
+	}
+	if err := p.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("regex: %w", err))
+	}
+	return errs
+}
+
+func validateTree[T any, P interface {
+	*T
+	Validate() error
+}](all, some []T, not P) []error {
+	var errs []error
+	for i := range all {
+		if err := P(&all[i]).Validate(); err != nil {
+			errs = append(errs, prefix("all["+strconv.Itoa(i)+"]", err))
+		}
+	}
+	for i := range some {
+		if err := P(&some[i]).Validate(); err != nil {
+			errs = append(errs, prefix("any["+strconv.Itoa(i)+"]", err))
+		}
+	}
+	if not != nil {
+		if err := not.Validate(); err != nil {
+			errs = append(errs, prefix("not", err))
+		}
+	}
+	return errs
+}
+
+// prefix puts path in front of every error joined in err.
+func prefix(path string, err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, prefix(path, e))
+		}
+		return errors.Join(errs...)
+	}
+	if p, ok := err.(*pathError); ok {
+		return &pathError{path + "." + p.path, p.err}
+	}
+	return &pathError{path, err}
+}
+
+type pathError struct {
+	path string
+	err  error
+}
+
+func (e *pathError) Error() string { return e.path + ": " + e.err.Error() }
+
+func (e *pathError) Unwrap() error { return e.err }
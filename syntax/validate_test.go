@@ -0,0 +1,86 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestMultiFieldMatch(t *testing.T) {
+	mixed := Env{
+		StrictEqual: "FOO",
+		Includes:    "bar",
+		EqualsAnyOf: []string{"X", "Y"},
+		EqualsMap:   map[string]string{"BAZ": "val"},
+	}
+	pr := PR{
+		StrictEqual: "FOO",
+		NotEqual:    "0",
+		EqualsAnyOf: []string{"1", "2"},
+		EqualsMap:   map[string]string{"BAZ": "val"},
+	}
+	tests := []struct {
+		name    string
+		data    map[string]string
+		env, pr bool
+	}{
+		{"all hold", map[string]string{"FOO": "1bar", "Y": "y", "BAZ": "val"}, true, false},
+		{"pr all hold", map[string]string{"FOO": "2", "BAZ": "val"}, false, true},
+		{"map fails", map[string]string{"FOO": "bar", "X": "x", "FOO2": "", "BAZ": "other"}, false, false},
+		{"any fails", map[string]string{"FOO": "bar", "BAZ": "val"}, false, false},
+		{"includes fails", map[string]string{"FOO": "baz", "X": "x", "BAZ": "val"}, false, false},
+		{"pr value not listed", map[string]string{"FOO": "3", "BAZ": "val"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mixed.Match(Map(tt.data)); got != tt.env {
+				t.Errorf("Env.Match() = %v, want %v", got, tt.env)
+			}
+			if got := pr.Match(Map(tt.data)); got != tt.pr {
+				t.Errorf("PR.Match() = %v, want %v", got, tt.pr)
+			}
+		})
+	}
+
+	if got, want := mixed.String(), `FOO contains "bar" && (X || Y) && BAZ == "val"`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if got, want := pr.String(), `FOO in ["1", "2"] && FOO != "0" && BAZ == "val"`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule interface{ Validate() error }
+		want string
+	}{
+		{"valid env", &Env{StrictEqual: "A", Includes: "x", EqualsAnyOf: []string{"B"}}, ""},
+		{"valid pr", &PR{StrictEqual: "A", NotEqual: "0", EqualsAnyOf: []string{"1"}}, ""},
+		{"empty", &Env{}, "rule is empty and never matches"},
+		{"includes without env", &Env{Includes: "x", EqualsAnyOf: []string{"B"}}, "includes is ignored without env"},
+		{"ne without env", &PR{NotEqual: "0", EqualsAnyOf: []string{"B"}}, "ne is ignored without env"},
+		{"unset contradiction", &Env{StrictEqual: "A", Includes: "x", Predicates: Predicates{Unset: true}}, "unset contradicts includes"},
+		{"unset and predicates", &PR{StrictEqual: "A", Predicates: Predicates{Unset: true, Prefix: "x"}}, "unset contradicts the other predicates"},
+		{"bad regex", &Env{StrictEqual: "A", Predicates: Predicates{Regex: "("}}, "regex: error parsing regexp: missing closing ): `(`"},
+		{"operator as equality key", &Env{EqualsMap: map[string]string{"env": "x"}}, `equality on "env" clashes with the operator of that name`},
+		{"pr operator as equality key", &PR{EqualsMap: map[string]string{"ne": "x", "regex": "y"}}, `equality on "ne" clashes with the operator of that name`},
+		{"ne is a variable for env", &Env{EqualsMap: map[string]string{"ne": "x"}}, ""},
+		{
+			"nested",
+			&EnvList{{StrictEqual: "A"}, {All: []Env{{StrictEqual: "B"}, {Not: &Env{Includes: "x"}}}}},
+			"[1].all[1].not: rule is empty and never matches\n[1].all[1].not: includes is ignored without env",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
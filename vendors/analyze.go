@@ -0,0 +1,151 @@
+package vendors
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+type FindingKind string
+
+const (
+	// FindingOverlap: both vendors match in some environment.
+	FindingOverlap FindingKind = "overlap"
+	// FindingSubsumed: B matches whenever A does.
+	FindingSubsumed FindingKind = "subsumed"
+	// FindingOrder: both vendors match and neither is nested in the other,
+	// so the one later in the list wins.
+	FindingOrder FindingKind = "order"
+	// FindingFallback: A reads Keys from CommonKeys and can match together
+	// with the generic fallback, which treats any of them as CI. B is empty.
+	FindingFallback FindingKind = "fallback"
+)
+
+// Severity tells findings that need fixing apart from those that only
+// describe the vendor list.
+type Severity string
+
+const (
+	SeverityError Severity = "error"
+	SeverityInfo  Severity = "info"
+)
+
+// Finding describes a relation between the Env rules of vendors A and B,
+// with an environment demonstrating it where there is one.
+type Finding struct {
+	Kind     FindingKind       `json:"kind"`
+	Severity Severity          `json:"severity"`
+	A        string            `json:"a"`
+	B        string            `json:"b,omitempty"`
+	Keys     []string          `json:"keys,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+}
+
+func (f Finding) String() string {
+	var s string
+	switch f.Kind {
+	case FindingOverlap:
+		s = fmt.Sprintf("%s and %s can match together", f.A, f.B)
+	case FindingSubsumed:
+		s = fmt.Sprintf("%s is subsumed by %s, which matches whenever it does", f.A, f.B)
+	case FindingOrder:
+		s = fmt.Sprintf("%s and %s are not nested in each other; %s wins only by coming later", f.A, f.B, f.B)
+	case FindingFallback:
+		s = fmt.Sprintf("%s and the generic fallback both read %s", f.A, strings.Join(f.Keys, ", "))
+	default:
+		s = fmt.Sprintf("%s: %s, %s", f.Kind, f.A, f.B)
+	}
+	if len(f.Env) == 0 {
+		return s
+	}
+	vars := make([]string, 0, len(f.Env))
+	for _, k := range slices.Sorted(maps.Keys(f.Env)) {
+		vars = append(vars, k+"="+f.Env[k])
+	}
+	return s + ": " + strings.Join(vars, " ")
+}
+
+// Analyze reports pairs of vendors in vs, in detection order, whose Env
+// rules read a common variable and can match at the same time, rules
+// subsumed by another vendor's, and pairs whose winner depends on their
+// order rather than on NestedIn. Vendors that share no variable are only
+// detected together when both are really present, so they are not reported.
+// Subsumption is only reported when proven. The generic fallback counts as
+// one more rule, any of CommonKeys, so vendors reading those variables are
+// reported against it, with SeverityInfo as the vendor rightly takes
+// precedence; every other finding is an error.
+func Analyze(vs []Vendor) []Finding {
+	keys := make([][]string, len(vs))
+	for i := range vs {
+		keys[i] = vs[i].Env.Keys()
+	}
+
+	var out []Finding
+	for i := range vs {
+		if f, ok := fallback(&vs[i], keys[i]); ok {
+			out = append(out, f)
+		}
+		for j := i + 1; j < len(vs); j++ {
+			a, b := &vs[i], &vs[j]
+			if !slices.ContainsFunc(keys[i], func(k string) bool { return slices.Contains(keys[j], k) }) {
+				continue
+			}
+			env, ok := syntax.Solve([]syntax.Rule{&a.Env, &b.Env}, nil)
+			if !ok {
+				continue
+			}
+			out = append(out, Finding{Kind: FindingOverlap, Severity: SeverityError, A: a.Constant, B: b.Constant, Env: env})
+
+			if f, ok := subsumed(a, b); ok {
+				out = append(out, f)
+			}
+			if f, ok := subsumed(b, a); ok {
+				out = append(out, f)
+			}
+			if !a.nestedIn(b) && !b.nestedIn(a) {
+				out = append(out, Finding{Kind: FindingOrder, Severity: SeverityError, A: a.Constant, B: b.Constant, Env: env})
+			}
+		}
+	}
+	return out
+}
+
+// subsumed reports a FindingSubsumed when b provably matches whenever a
+// does.
+func subsumed(a, b *Vendor) (Finding, bool) {
+	if !syntax.Unsatisfiable([]syntax.Rule{&a.Env}, []syntax.Rule{&b.Env}) {
+		return Finding{}, false
+	}
+	return Finding{Kind: FindingSubsumed, Severity: SeverityError, A: a.Constant, B: b.Constant}, true
+}
+
+// fallback reports v, whose Env reads keys, when it shares a variable with
+// the generic fallback and both can match at the same time.
+func fallback(v *Vendor, keys []string) (Finding, bool) {
+	var shared []string
+	for _, k := range keys {
+		if slices.Contains(commonKeys, k) {
+			shared = append(shared, k)
+		}
+	}
+	if len(shared) == 0 {
+		return Finding{}, false
+	}
+	env, ok := syntax.Solve([]syntax.Rule{&v.Env, &syntax.Env{EqualsAnyOf: commonKeys}}, nil)
+	if !ok {
+		return Finding{}, false
+	}
+	return Finding{Kind: FindingFallback, Severity: SeverityInfo, A: v.Constant, Keys: shared, Env: env}, true
+}
+
+// Errors reports whether findings include one with SeverityError.
+func Errors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+func (c *Catalog) Analyze() []Finding {
+	return Analyze(c.Vendors())
+}
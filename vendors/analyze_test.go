@@ -0,0 +1,97 @@
+package vendors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func TestAnalyze(t *testing.T) {
+	anyCI := Vendor{Constant: "ANY_CI", Env: syntax.EnvList{syntax.MustParseEnv("CI")}}
+	nested := Vendor{Constant: "NESTED", Env: syntax.EnvList{syntax.MustParseEnv(`CI && TOOL`)}, NestedIn: []string{AnyVendor}}
+	numeric := Vendor{Constant: "NUMERIC", Env: syntax.EnvList{syntax.MustParseEnv("FOO is numeric")}}
+	prefix := Vendor{Constant: "PREFIX", Env: syntax.EnvList{syntax.MustParseEnv(`FOO startsWith "1"`)}}
+	const woodpecker = "CI=woodpecker"
+
+	tests := []struct {
+		name string
+		vs   []Vendor
+		want []string
+	}{
+		{
+			name: "built-in vendors",
+			vs:   All,
+			want: []string{
+				"CODESHIP and the generic fallback both read CI_NAME: CI_NAME=codeship",
+				"JENKINS and the generic fallback both read BUILD_ID: BUILD_ID=true JENKINS_URL=true",
+				"SOURCEHUT and the generic fallback both read CI_NAME: CI_NAME=sourcehut",
+				"TASKCLUSTER and the generic fallback both read RUN_ID: RUN_ID=true TASK_ID=true",
+				"WOODPECKER and the generic fallback both read CI: " + woodpecker,
+			},
+		},
+		{
+			name: "same variable, different values",
+			vs:   []Vendor{VendorCODESHIP, VendorSOURCEHUT},
+			want: []string{
+				"CODESHIP and the generic fallback both read CI_NAME: CI_NAME=codeship",
+				"SOURCEHUT and the generic fallback both read CI_NAME: CI_NAME=sourcehut",
+			},
+		},
+		{
+			// FOO=2 is numeric without the prefix, so neither subsumes
+			// the other.
+			name: "not subsumed",
+			vs:   []Vendor{numeric, prefix},
+			want: []string{
+				"NUMERIC and PREFIX can match together: FOO=10",
+				"NUMERIC and PREFIX are not nested in each other; PREFIX wins only by coming later: FOO=10",
+			},
+		},
+		{
+			name: "subsumed and order dependent",
+			vs:   []Vendor{VendorWOODPECKER, anyCI},
+			want: []string{
+				"WOODPECKER and the generic fallback both read CI: " + woodpecker,
+				"WOODPECKER and ANY_CI can match together: " + woodpecker,
+				"WOODPECKER is subsumed by ANY_CI, which matches whenever it does",
+				"WOODPECKER and ANY_CI are not nested in each other; ANY_CI wins only by coming later: " + woodpecker,
+				"ANY_CI and the generic fallback both read CI: CI=true",
+			},
+		},
+		{
+			name: "nesting decides",
+			vs:   []Vendor{anyCI, nested},
+			want: []string{
+				"ANY_CI and the generic fallback both read CI: CI=true",
+				"ANY_CI and NESTED can match together: CI=true TOOL=true",
+				"NESTED is subsumed by ANY_CI, which matches whenever it does",
+				"NESTED and the generic fallback both read CI: CI=true TOOL=true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range Analyze(tt.vs) {
+				got = append(got, f.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSeverity(t *testing.T) {
+	for _, f := range Analyze(All) {
+		if f.Severity != SeverityInfo {
+			t.Errorf("built-in vendors: %s has severity %s, want info", f, f.Severity)
+		}
+	}
+	anyCI := Vendor{Constant: "ANY_CI", Env: syntax.EnvList{syntax.MustParseEnv("CI")}}
+	if !Errors(Analyze([]Vendor{VendorWOODPECKER, anyCI})) {
+		t.Error("a subsumed vendor should be an error")
+	}
+}
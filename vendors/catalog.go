@@ -0,0 +1,158 @@
+package vendors
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrDuplicateVendor = errors.New("duplicate vendor")
+	ErrUnknownVendor   = errors.New("unknown vendor")
+)
+
+// Catalog is an ordered, editable set of vendors keyed by constant.
+//
+// Detection lets the last matching vendor win, so Vendors returns entries
+// sorted by ascending priority: a vendor registered with a higher priority
+// takes precedence over every lower one it matches alongside. Entries with
+// equal priority keep their registration order.
+type Catalog struct {
+	mu      sync.RWMutex
+	entries []entry
+}
+
+type entry struct {
+	vendor   Vendor
+	priority int
+}
+
+func NewCatalog(vs ...Vendor) *Catalog {
+	c := &Catalog{}
+	for _, v := range vs {
+		// duplicates in the seed list keep the later definition
+		if i := c.index(v.Constant); i >= 0 {
+			c.entries[i].vendor = v
+			continue
+		}
+		c.entries = append(c.entries, entry{vendor: v})
+	}
+	return c
+}
+
+func (c *Catalog) Register(v Vendor, priority int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.index(v.Constant) >= 0 {
+		return fmt.Errorf("%w %q", ErrDuplicateVendor, v.Constant)
+	}
+	c.entries = append(c.entries, entry{vendor: v, priority: priority})
+	return nil
+}
+
+func (c *Catalog) Override(constant string, v Vendor) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.index(constant)
+	if i < 0 {
+		return fmt.Errorf("%w %q", ErrUnknownVendor, constant)
+	}
+	if v.Constant == "" {
+		v.Constant = constant
+	}
+	if j := c.index(v.Constant); j >= 0 && j != i {
+		return fmt.Errorf("%w %q", ErrDuplicateVendor, v.Constant)
+	}
+	c.entries[i].vendor = v
+	return nil
+}
+
+func (c *Catalog) SetPriority(constant string, priority int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.index(constant)
+	if i < 0 {
+		return fmt.Errorf("%w %q", ErrUnknownVendor, constant)
+	}
+	c.entries[i].priority = priority
+	return nil
+}
+
+func (c *Catalog) Remove(constant string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := c.index(constant)
+	if i < 0 {
+		return false
+	}
+	c.entries = slices.Delete(c.entries, i, i+1)
+	return true
+}
+
+func (c *Catalog) Lookup(constant string) (Vendor, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i := c.index(constant); i >= 0 {
+		return c.entries[i].vendor, true
+	}
+	return Vendor{}, false
+}
+
+func (c *Catalog) LookupName(name string) (Vendor, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, e := range c.entries {
+		if e.vendor.Name == name {
+			return e.vendor, true
+		}
+	}
+	return Vendor{}, false
+}
+
+func (c *Catalog) Priority(constant string) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i := c.index(constant); i >= 0 {
+		return c.entries[i].priority, true
+	}
+	return 0, false
+}
+
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.entries)
+}
+
+// Vendors returns the vendors in evaluation order, lowest priority first.
+func (c *Catalog) Vendors() []Vendor {
+	c.mu.RLock()
+	entries := slices.Clone(c.entries)
+	c.mu.RUnlock()
+
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	out := make([]Vendor, len(entries))
+	for i, e := range entries {
+		out[i] = e.vendor
+	}
+	return out
+}
+
+func (c *Catalog) index(constant string) int {
+	return slices.IndexFunc(c.entries, func(e entry) bool {
+		return e.vendor.Constant == constant
+	})
+}
@@ -0,0 +1,110 @@
+package vendors
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func constants(vs []Vendor) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = v.Constant
+	}
+	return out
+}
+
+func TestCatalogRegister(t *testing.T) {
+	c := NewCatalog(VendorGITHUB_ACTIONS, VendorJENKINS)
+
+	inhouse := Vendor{
+		Name:     "In-house CI",
+		Constant: "INHOUSE",
+		Env:      syntax.EnvList{{StrictEqual: "INHOUSE_CI"}},
+	}
+	if err := c.Register(inhouse, 0); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := c.Register(inhouse, 0); !errors.Is(err, ErrDuplicateVendor) {
+		t.Errorf("Register duplicate = %v, want ErrDuplicateVendor", err)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len = %d, want 3", c.Len())
+	}
+
+	if v, ok := c.LookupName("In-house CI"); !ok || v.Constant != "INHOUSE" {
+		t.Errorf("LookupName = %+v, %v", v, ok)
+	}
+}
+
+func TestCatalogPriority(t *testing.T) {
+	c := NewCatalog(VendorGITHUB_ACTIONS, VendorJENKINS, VendorTRAVIS)
+
+	if err := c.SetPriority("GITHUB_ACTIONS", 10); err != nil {
+		t.Fatalf("SetPriority failed: %v", err)
+	}
+	if err := c.Register(Vendor{Constant: "LOW"}, -1); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	got := constants(c.Vendors())
+	want := []string{"LOW", "JENKINS", "TRAVIS", "GITHUB_ACTIONS"}
+	if len(got) != len(want) {
+		t.Fatalf("Vendors = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Vendors = %v, want %v", got, want)
+		}
+	}
+
+	// extreme priorities must not overflow the comparison
+	if err := c.Register(Vendor{Constant: "MIN"}, math.MinInt); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := c.SetPriority("JENKINS", math.MaxInt); err != nil {
+		t.Fatalf("SetPriority failed: %v", err)
+	}
+	if got := constants(c.Vendors()); got[0] != "MIN" || got[len(got)-1] != "JENKINS" {
+		t.Errorf("Vendors = %v, want MIN first and JENKINS last", got)
+	}
+
+	if err := c.SetPriority("NOPE", 1); !errors.Is(err, ErrUnknownVendor) {
+		t.Errorf("SetPriority unknown = %v, want ErrUnknownVendor", err)
+	}
+}
+
+func TestCatalogOverrideAndRemove(t *testing.T) {
+	c := NewCatalog(All...)
+
+	custom := VendorJENKINS
+	custom.Env = syntax.EnvList{{StrictEqual: "JENKINS_URL"}}
+	custom.Constant = ""
+	if err := c.Override("JENKINS", custom); err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	v, ok := c.Lookup("JENKINS")
+	if !ok || len(v.Env) != 1 || v.Constant != "JENKINS" {
+		t.Errorf("Lookup after Override = %+v, %v", v, ok)
+	}
+
+	if err := c.Override("NOPE", custom); !errors.Is(err, ErrUnknownVendor) {
+		t.Errorf("Override unknown = %v, want ErrUnknownVendor", err)
+	}
+	custom.Constant = "TRAVIS"
+	if err := c.Override("JENKINS", custom); !errors.Is(err, ErrDuplicateVendor) {
+		t.Errorf("Override onto existing constant = %v, want ErrDuplicateVendor", err)
+	}
+
+	if !c.Remove("JENKINS") || c.Remove("JENKINS") {
+		t.Error("Remove should succeed once")
+	}
+	if _, ok := c.Lookup("JENKINS"); ok {
+		t.Error("JENKINS should be gone")
+	}
+	if c.Len() != len(All)-1 {
+		t.Errorf("Len = %d, want %d", c.Len(), len(All)-1)
+	}
+}
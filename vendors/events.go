@@ -0,0 +1,32 @@
+package vendors
+
+import "github.com/startracex/ciinfo/syntax"
+
+type Event string
+
+const (
+	EventUnknown     Event = ""
+	EventPush        Event = "push"
+	EventTag         Event = "tag"
+	EventPullRequest Event = "pull_request"
+	EventSchedule    Event = "schedule"
+	EventManual      Event = "manual"
+	EventMergeQueue  Event = "merge_queue"
+	EventAPI         Event = "api"
+)
+
+// EventRule classifies a build as Event when When matches. Rules are tried
+// in order and the first match wins.
+type EventRule struct {
+	Event Event     `json:"event"`
+	When  syntax.PR `json:"when"`
+}
+
+func (v *Vendor) ClassifyEvent(env syntax.Environment) Event {
+	for i := range v.Events {
+		if v.Events[i].When.Match(env) {
+			return v.Events[i].Event
+		}
+	}
+	return EventUnknown
+}
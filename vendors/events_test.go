@@ -0,0 +1,38 @@
+package vendors
+
+import (
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func TestClassifyEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		vendor Vendor
+		env    map[string]string
+		want   Event
+	}{
+		{"github push", VendorGITHUB_ACTIONS, map[string]string{"GITHUB_EVENT_NAME": "push"}, EventPush},
+		{"github pull_request_target", VendorGITHUB_ACTIONS, map[string]string{"GITHUB_EVENT_NAME": "pull_request_target"}, EventPullRequest},
+		{"github merge queue", VendorGITHUB_ACTIONS, map[string]string{"GITHUB_EVENT_NAME": "merge_group"}, EventMergeQueue},
+		{"github dispatch", VendorGITHUB_ACTIONS, map[string]string{"GITHUB_EVENT_NAME": "workflow_dispatch"}, EventManual},
+		{"github unknown", VendorGITHUB_ACTIONS, map[string]string{"GITHUB_EVENT_NAME": "release"}, EventUnknown},
+		{"gitlab schedule", VendorGITLAB, map[string]string{"CI_PIPELINE_SOURCE": "schedule"}, EventSchedule},
+		{"gitlab tag", VendorGITLAB, map[string]string{"CI_PIPELINE_SOURCE": "push", "CI_COMMIT_TAG": "v1"}, EventTag},
+		{"azure batched", VendorAZURE_PIPELINES, map[string]string{"BUILD_REASON": "BatchedCI"}, EventPush},
+		{"buildkite pr", VendorBUILDKITE, map[string]string{"BUILDKITE_SOURCE": "webhook", "BUILDKITE_PULL_REQUEST": "12"}, EventPullRequest},
+		{"buildkite push", VendorBUILDKITE, map[string]string{"BUILDKITE_SOURCE": "webhook", "BUILDKITE_PULL_REQUEST": "false"}, EventPush},
+		{"drone cron", VendorDRONE, map[string]string{"DRONE_BUILD_EVENT": "cron"}, EventSchedule},
+		{"circle tag", VendorCIRCLE, map[string]string{"CIRCLE_TAG": "v1", "CIRCLE_BRANCH": ""}, EventTag},
+		{"no rules", VendorDSARI, map[string]string{"DSARI": "1"}, EventUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.vendor.ClassifyEvent(syntax.Map(tt.env)); got != tt.want {
+				t.Errorf("ClassifyEvent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
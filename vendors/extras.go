@@ -0,0 +1,832 @@
+package vendors
+
+import "github.com/startracex/ciinfo/syntax"
+
+// extras holds the hand-written rules that ci-info's vendors.json does not
+// carry. The generated vendors pick them up by constant through extend; a PR
+// rule given here replaces the one from vendors.json.
+var extras = map[string]Vendor{
+	"AGOLA": {
+		Meta: &Meta{
+			Commit: keys("AGOLA_GIT_COMMITSHA"),
+			Branch: keys("AGOLA_GIT_BRANCH"),
+			Tag:    keys("AGOLA_GIT_TAG"),
+		},
+		PullRequest: &PullRequest{
+			Number: keys("AGOLA_PULL_REQUEST_ID"),
+		},
+	},
+	"APPCIRCLE": {
+		Meta: &Meta{
+			Commit:  keys("AC_GIT_COMMIT"),
+			Branch:  keys("AC_GIT_BRANCH"),
+			Tag:     keys("AC_GIT_TAG"),
+			BuildID: keys("AC_BUILD_ID"),
+		},
+	},
+	"APPVEYOR": {
+		Meta: &Meta{
+			Commit:      keys("APPVEYOR_PULL_REQUEST_HEAD_COMMIT", "APPVEYOR_REPO_COMMIT"),
+			Branch:      keys("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH", "APPVEYOR_REPO_BRANCH"),
+			Tag:         keys("APPVEYOR_REPO_TAG_NAME"),
+			BuildNumber: keys("APPVEYOR_BUILD_NUMBER"),
+			BuildID:     keys("APPVEYOR_BUILD_ID"),
+			JobID:       keys("APPVEYOR_JOB_ID"),
+			BuildURL:    tmpl("${APPVEYOR_URL}/project/${APPVEYOR_ACCOUNT_NAME}/${APPVEYOR_PROJECT_SLUG}/builds/${APPVEYOR_BUILD_ID}"),
+			JobURL:      tmpl("${APPVEYOR_URL}/project/${APPVEYOR_ACCOUNT_NAME}/${APPVEYOR_PROJECT_SLUG}/build/job/${APPVEYOR_JOB_ID}"),
+			Repo:        keys("APPVEYOR_REPO_NAME"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("APPVEYOR_PULL_REQUEST_NUMBER"),
+			SourceBranch: keys("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH"),
+			TargetBranch: keys("APPVEYOR_REPO_BRANCH"),
+			HeadSHA:      keys("APPVEYOR_PULL_REQUEST_HEAD_COMMIT"),
+		},
+		Corroborate: []string{"APPVEYOR_BUILD_ID", "APPVEYOR_JOB_ID", "APPVEYOR_BUILD_NUMBER"},
+		Events: []EventRule{
+			on(EventSchedule, "APPVEYOR_SCHEDULED_BUILD", "True", "true"),
+			on(EventManual, "APPVEYOR_FORCED_BUILD", "True", "true"),
+			on(EventManual, "APPVEYOR_RE_BUILD", "True", "true"),
+			on(EventTag, "APPVEYOR_REPO_TAG", "True", "true"),
+			on(EventPullRequest, "APPVEYOR_PULL_REQUEST_NUMBER"),
+			on(EventPush, "APPVEYOR_REPO_COMMIT"),
+		},
+	},
+	"CODEBUILD": {
+		Meta: &Meta{
+			Commit:      keys("CODEBUILD_RESOLVED_SOURCE_VERSION"),
+			Branch:      syntax.Extract{ref("CODEBUILD_WEBHOOK_HEAD_REF", "refs/heads/")},
+			Tag:         syntax.Extract{ref("CODEBUILD_WEBHOOK_HEAD_REF", "refs/tags/")},
+			BuildNumber: keys("CODEBUILD_BUILD_NUMBER"),
+			BuildID:     keys("CODEBUILD_BUILD_ID"),
+			BuildURL:    tmpl("https://${AWS_REGION}.console.aws.amazon.com/codesuite/codebuild/projects/build/${CODEBUILD_BUILD_ID}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CODEBUILD_SOURCE_VERSION"),
+			SourceBranch: syntax.Extract{{Env: "CODEBUILD_WEBHOOK_HEAD_REF", TrimPrefix: "refs/heads/"}},
+			TargetBranch: syntax.Extract{{Env: "CODEBUILD_WEBHOOK_BASE_REF", TrimPrefix: "refs/heads/"}},
+			HeadSHA:      keys("CODEBUILD_RESOLVED_SOURCE_VERSION"),
+		},
+		Corroborate: []string{"CODEBUILD_BUILD_ID", "CODEBUILD_SRC_DIR", "CODEBUILD_BUILD_NUMBER"},
+		Events: []EventRule{
+			on(EventPush, "CODEBUILD_WEBHOOK_EVENT", "PUSH"),
+			on(EventPullRequest, "CODEBUILD_WEBHOOK_EVENT", "PULL_REQUEST_CREATED", "PULL_REQUEST_UPDATED", "PULL_REQUEST_REOPENED"),
+		},
+	},
+	"AZURE_PIPELINES": {
+		Meta: &Meta{
+			Commit: keys("BUILD_SOURCEVERSION"),
+			Branch: syntax.Extract{
+				ref("SYSTEM_PULLREQUEST_SOURCEBRANCH", "refs/heads/"),
+				ref("BUILD_SOURCEBRANCH", "refs/heads/"),
+			},
+			Tag:         syntax.Extract{ref("BUILD_SOURCEBRANCH", "refs/tags/")},
+			BuildNumber: keys("BUILD_BUILDNUMBER"),
+			BuildID:     keys("BUILD_BUILDID"),
+			JobID:       keys("SYSTEM_JOBID"),
+			BuildURL:    tmpl("${SYSTEM_COLLECTIONURI}${SYSTEM_TEAMPROJECT}/_build/results?buildId=${BUILD_BUILDID}"),
+			JobURL:      tmpl("${SYSTEM_COLLECTIONURI}${SYSTEM_TEAMPROJECT}/_build/results?buildId=${BUILD_BUILDID}&view=logs&j=${SYSTEM_JOBID}"),
+			Repo:        keys("BUILD_REPOSITORY_NAME"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID"),
+			SourceBranch: syntax.Extract{{Env: "SYSTEM_PULLREQUEST_SOURCEBRANCH", TrimPrefix: "refs/heads/"}},
+			TargetBranch: syntax.Extract{{Env: "SYSTEM_PULLREQUEST_TARGETBRANCH", TrimPrefix: "refs/heads/"}},
+			HeadSHA:      keys("SYSTEM_PULLREQUEST_SOURCECOMMITID"),
+		},
+		Corroborate: []string{"BUILD_BUILDID", "SYSTEM_COLLECTIONURI", "AGENT_NAME", "BUILD_SOURCEVERSION"},
+		Events: []EventRule{
+			on(EventPush, "BUILD_REASON", "IndividualCI", "BatchedCI"),
+			on(EventPullRequest, "BUILD_REASON", "PullRequest"),
+			on(EventSchedule, "BUILD_REASON", "Schedule"),
+			on(EventManual, "BUILD_REASON", "Manual"),
+			on(EventAPI, "BUILD_REASON", "ResourceTrigger", "BuildCompletion"),
+		},
+	},
+	"BAMBOO": {
+		Meta: &Meta{
+			Commit:      keys("bamboo_planRepository_revision"),
+			Branch:      keys("bamboo_planRepository_branch"),
+			BuildNumber: keys("bamboo_buildNumber"),
+			BuildID:     keys("bamboo_buildResultKey"),
+			BuildURL:    keys("bamboo_buildResultsUrl"),
+		},
+		Corroborate: []string{"bamboo_buildNumber", "bamboo_buildResultKey"},
+	},
+	"BITBUCKET": {
+		Meta: &Meta{
+			Commit:      keys("BITBUCKET_COMMIT"),
+			Branch:      keys("BITBUCKET_BRANCH"),
+			Tag:         keys("BITBUCKET_TAG"),
+			BuildNumber: keys("BITBUCKET_BUILD_NUMBER"),
+			BuildID:     keys("BITBUCKET_PIPELINE_UUID"),
+			JobID:       keys("BITBUCKET_STEP_UUID"),
+			BuildURL:    tmpl("https://bitbucket.org/${BITBUCKET_REPO_FULL_NAME}/pipelines/results/${BITBUCKET_BUILD_NUMBER}"),
+			Repo:        keys("BITBUCKET_REPO_FULL_NAME"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("BITBUCKET_PR_ID"),
+			SourceBranch: keys("BITBUCKET_BRANCH"),
+			TargetBranch: keys("BITBUCKET_PR_DESTINATION_BRANCH"),
+			HeadSHA:      keys("BITBUCKET_COMMIT"),
+			BaseSHA:      keys("BITBUCKET_PR_DESTINATION_COMMIT"),
+		},
+		Corroborate: []string{"BITBUCKET_BUILD_NUMBER", "BITBUCKET_REPO_SLUG", "BITBUCKET_PIPELINE_UUID"},
+		Events: []EventRule{
+			on(EventPullRequest, "BITBUCKET_PR_ID"),
+			on(EventTag, "BITBUCKET_TAG"),
+			on(EventPush, "BITBUCKET_BRANCH"),
+		},
+	},
+	"BITRISE": {
+		Meta: &Meta{
+			Commit:      keys("BITRISE_GIT_COMMIT"),
+			Branch:      keys("BITRISE_GIT_BRANCH"),
+			Tag:         keys("BITRISE_GIT_TAG"),
+			BuildNumber: keys("BITRISE_BUILD_NUMBER"),
+			BuildID:     keys("BITRISE_BUILD_SLUG"),
+			BuildURL:    keys("BITRISE_BUILD_URL"),
+			Repo:        tmpl("${BITRISEIO_GIT_REPOSITORY_OWNER}/${BITRISEIO_GIT_REPOSITORY_SLUG}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("BITRISE_PULL_REQUEST"),
+			SourceBranch: keys("BITRISE_GIT_BRANCH"),
+			TargetBranch: keys("BITRISEIO_GIT_BRANCH_DEST"),
+			HeadSHA:      keys("BITRISE_GIT_COMMIT"),
+		},
+		Corroborate: []string{"BITRISE_BUILD_NUMBER", "BITRISE_BUILD_SLUG", "BITRISE_APP_SLUG"},
+	},
+	"BUDDY": {
+		Meta: &Meta{
+			Commit:      keys("BUDDY_EXECUTION_REVISION"),
+			Branch:      keys("BUDDY_EXECUTION_BRANCH"),
+			Tag:         keys("BUDDY_EXECUTION_TAG"),
+			BuildNumber: keys("BUDDY_EXECUTION_ID"),
+			BuildID:     keys("BUDDY_EXECUTION_ID"),
+			BuildURL:    keys("BUDDY_EXECUTION_URL"),
+			Repo:        keys("BUDDY_REPO_SLUG"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("BUDDY_EXECUTION_PULL_REQUEST_NO", "BUDDY_EXECUTION_PULL_REQUEST_ID"),
+			SourceBranch: keys("BUDDY_EXECUTION_PULL_REQUEST_HEAD_BRANCH"),
+			TargetBranch: keys("BUDDY_EXECUTION_PULL_REQUEST_BASE_BRANCH"),
+			HeadSHA:      keys("BUDDY_EXECUTION_REVISION"),
+		},
+	},
+	"BUILDKITE": {
+		Meta: &Meta{
+			Commit:      keys("BUILDKITE_COMMIT"),
+			Branch:      keys("BUILDKITE_BRANCH"),
+			Tag:         keys("BUILDKITE_TAG"),
+			BuildNumber: keys("BUILDKITE_BUILD_NUMBER"),
+			BuildID:     keys("BUILDKITE_BUILD_ID"),
+			JobID:       keys("BUILDKITE_JOB_ID"),
+			BuildURL:    keys("BUILDKITE_BUILD_URL"),
+			JobURL:      tmpl("${BUILDKITE_BUILD_URL}#${BUILDKITE_JOB_ID}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("BUILDKITE_PULL_REQUEST"),
+			SourceBranch: keys("BUILDKITE_BRANCH"),
+			TargetBranch: keys("BUILDKITE_PULL_REQUEST_BASE_BRANCH"),
+			HeadSHA:      keys("BUILDKITE_COMMIT"),
+		},
+		Corroborate: []string{"BUILDKITE_BUILD_ID", "BUILDKITE_JOB_ID", "BUILDKITE_AGENT_NAME", "BUILDKITE_COMMIT"},
+		Events: []EventRule{
+			{Event: EventPullRequest, When: syntax.PR{StrictEqual: "BUILDKITE_PULL_REQUEST", NotEqual: "false"}},
+			on(EventTag, "BUILDKITE_TAG"),
+			on(EventPush, "BUILDKITE_SOURCE", "webhook"),
+			on(EventSchedule, "BUILDKITE_SOURCE", "schedule"),
+			on(EventManual, "BUILDKITE_SOURCE", "ui"),
+			on(EventAPI, "BUILDKITE_SOURCE", "api", "trigger_job"),
+		},
+	},
+	"CIRCLE": {
+		Meta: &Meta{
+			Commit:      keys("CIRCLE_SHA1"),
+			Branch:      keys("CIRCLE_BRANCH"),
+			Tag:         keys("CIRCLE_TAG"),
+			BuildNumber: keys("CIRCLE_BUILD_NUM"),
+			BuildID:     keys("CIRCLE_WORKFLOW_ID"),
+			JobID:       keys("CIRCLE_WORKFLOW_JOB_ID"),
+			BuildURL:    tmpl("https://app.circleci.com/pipelines/workflows/${CIRCLE_WORKFLOW_ID}"),
+			JobURL:      keys("CIRCLE_BUILD_URL"),
+			Repo:        tmpl("${CIRCLE_PROJECT_USERNAME}/${CIRCLE_PROJECT_REPONAME}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CIRCLE_PR_NUMBER", "CIRCLE_PULL_REQUEST"),
+			SourceBranch: keys("CIRCLE_BRANCH"),
+			HeadSHA:      keys("CIRCLE_SHA1"),
+		},
+		Corroborate: []string{"CIRCLE_BUILD_NUM", "CIRCLE_JOB", "CIRCLE_WORKFLOW_ID", "CIRCLE_SHA1"},
+		Events: []EventRule{
+			on(EventPullRequest, "CIRCLE_PULL_REQUEST"),
+			on(EventTag, "CIRCLE_TAG"),
+			on(EventPush, "CIRCLE_BRANCH"),
+		},
+	},
+	"CIRRUS": {
+		Meta: &Meta{
+			Commit:      keys("CIRRUS_CHANGE_IN_REPO"),
+			Branch:      keys("CIRRUS_BRANCH"),
+			Tag:         keys("CIRRUS_TAG"),
+			BuildNumber: keys("CIRRUS_BUILD_ID"),
+			BuildID:     keys("CIRRUS_BUILD_ID"),
+			JobID:       keys("CIRRUS_TASK_ID"),
+			BuildURL:    tmpl("https://cirrus-ci.com/build/${CIRRUS_BUILD_ID}"),
+			JobURL:      tmpl("https://cirrus-ci.com/task/${CIRRUS_TASK_ID}"),
+			Repo:        keys("CIRRUS_REPO_FULL_NAME"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CIRRUS_PR"),
+			TargetBranch: keys("CIRRUS_BASE_BRANCH"),
+			HeadSHA:      keys("CIRRUS_CHANGE_IN_REPO"),
+			BaseSHA:      keys("CIRRUS_BASE_SHA"),
+		},
+		Corroborate: []string{"CIRRUS_BUILD_ID", "CIRRUS_TASK_ID", "CIRRUS_REPO_FULL_NAME"},
+		Events: []EventRule{
+			on(EventSchedule, "CIRRUS_CRON"),
+			on(EventPullRequest, "CIRRUS_PR"),
+			on(EventTag, "CIRRUS_TAG"),
+			on(EventPush, "CIRRUS_BRANCH"),
+		},
+	},
+	"CLOUDFLARE_PAGES": {
+		Meta: &Meta{
+			Commit: keys("CF_PAGES_COMMIT_SHA"),
+			Branch: keys("CF_PAGES_BRANCH"),
+		},
+		Corroborate: []string{"CF_PAGES_COMMIT_SHA", "CF_PAGES_BRANCH", "CF_PAGES_URL"},
+	},
+	"CLOUDFLARE_WORKERS": {
+		Meta: &Meta{
+			Commit:  keys("WORKERS_CI_COMMIT_SHA"),
+			Branch:  keys("WORKERS_CI_BRANCH"),
+			BuildID: keys("WORKERS_CI_BUILD_UUID"),
+		},
+	},
+	"CODEFRESH": {
+		Meta: &Meta{
+			Commit:   keys("CF_REVISION"),
+			Branch:   keys("CF_BRANCH"),
+			BuildID:  keys("CF_BUILD_ID"),
+			BuildURL: keys("CF_BUILD_URL"),
+			Repo:     tmpl("${CF_REPO_OWNER}/${CF_REPO_NAME}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CF_PULL_REQUEST_NUMBER", "CF_PULL_REQUEST_ID"),
+			SourceBranch: keys("CF_BRANCH"),
+			TargetBranch: keys("CF_PULL_REQUEST_TARGET"),
+			HeadSHA:      keys("CF_REVISION"),
+		},
+		Corroborate: []string{"CF_BUILD_URL", "CF_REVISION"},
+	},
+	"CODEMAGIC": {
+		Meta: &Meta{
+			Commit:      keys("CM_COMMIT"),
+			Branch:      keys("CM_BRANCH"),
+			Tag:         keys("CM_TAG"),
+			BuildNumber: keys("BUILD_NUMBER"),
+			BuildID:     keys("CM_BUILD_ID"),
+			BuildURL:    tmpl("https://codemagic.io/app/${CM_PROJECT_ID}/build/${CM_BUILD_ID}"),
+			Repo:        keys("CM_REPO_SLUG"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CM_PULL_REQUEST_NUMBER"),
+			SourceBranch: keys("CM_BRANCH"),
+			TargetBranch: keys("CM_PULL_REQUEST_DEST"),
+			HeadSHA:      keys("CM_COMMIT"),
+		},
+		Corroborate: []string{"CM_BUILD_DIR", "CM_PROJECT_ID"},
+	},
+	"CODESHIP": {
+		Meta: &Meta{
+			Commit:      keys("CI_COMMIT_ID"),
+			Branch:      keys("CI_BRANCH"),
+			BuildNumber: keys("CI_BUILD_NUMBER"),
+			BuildID:     keys("CI_BUILD_ID"),
+			BuildURL:    keys("CI_BUILD_URL"),
+			Repo:        keys("CI_REPO_NAME"),
+		},
+	},
+	"DRONE": {
+		Meta: &Meta{
+			Commit:      keys("DRONE_COMMIT_SHA"),
+			Branch:      keys("DRONE_SOURCE_BRANCH", "DRONE_BRANCH"),
+			Tag:         keys("DRONE_TAG"),
+			BuildNumber: keys("DRONE_BUILD_NUMBER"),
+			BuildID:     keys("DRONE_BUILD_NUMBER"),
+			JobID:       keys("DRONE_STEP_NUMBER"),
+			BuildURL:    keys("DRONE_BUILD_LINK"),
+			Repo:        keys("DRONE_REPO"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("DRONE_PULL_REQUEST"),
+			SourceBranch: keys("DRONE_SOURCE_BRANCH"),
+			TargetBranch: keys("DRONE_TARGET_BRANCH"),
+			HeadSHA:      keys("DRONE_COMMIT_SHA"),
+		},
+		Corroborate: []string{"DRONE_BUILD_NUMBER", "DRONE_REPO", "DRONE_COMMIT_SHA"},
+		Events:      droneEvents,
+	},
+	"EARTHLY": {
+		NestedIn: []string{AnyVendor},
+	},
+	"EAS": {
+		Meta: &Meta{
+			Commit:  keys("EAS_BUILD_GIT_COMMIT_HASH"),
+			BuildID: keys("EAS_BUILD_ID"),
+		},
+	},
+	"GERRIT": {
+		Meta: &Meta{
+			Commit: keys("GERRIT_PATCHSET_REVISION", "GERRIT_NEWREV"),
+			Branch: keys("GERRIT_BRANCH"),
+			Repo:   keys("GERRIT_PROJECT"),
+		},
+		NestedIn: []string{"JENKINS", "HUDSON"},
+	},
+	"GITEA_ACTIONS": {
+		PR:          githubPR,
+		Meta:        githubMeta,
+		PullRequest: githubPullRequest,
+		NestedIn:    []string{"GITHUB_ACTIONS"},
+		Corroborate: []string{"GITHUB_RUN_ID", "GITHUB_WORKSPACE", "GITHUB_REPOSITORY"},
+		Events:      githubEvents,
+	},
+	"GITHUB_ACTIONS": {
+		PR:          githubPR,
+		Meta:        githubMeta,
+		PullRequest: githubPullRequest,
+		Corroborate: []string{"GITHUB_RUN_ID", "RUNNER_OS", "GITHUB_WORKSPACE", "GITHUB_REPOSITORY", "GITHUB_SHA"},
+		Events:      githubEvents,
+	},
+	"GITLAB": {
+		Meta: &Meta{
+			Commit:      keys("CI_COMMIT_SHA"),
+			Branch:      keys("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH"),
+			Tag:         keys("CI_COMMIT_TAG"),
+			BuildNumber: keys("CI_PIPELINE_IID"),
+			BuildID:     keys("CI_PIPELINE_ID"),
+			JobID:       keys("CI_JOB_ID"),
+			BuildURL:    keys("CI_PIPELINE_URL"),
+			JobURL:      keys("CI_JOB_URL"),
+			Repo:        keys("CI_PROJECT_PATH"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CI_MERGE_REQUEST_IID"),
+			SourceBranch: keys("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"),
+			TargetBranch: keys("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"),
+			HeadSHA:      keys("CI_MERGE_REQUEST_SOURCE_BRANCH_SHA", "CI_COMMIT_SHA"),
+			BaseSHA:      keys("CI_MERGE_REQUEST_DIFF_BASE_SHA", "CI_MERGE_REQUEST_TARGET_BRANCH_SHA"),
+		},
+		Corroborate: []string{"CI_JOB_ID", "CI_PIPELINE_ID", "CI_PROJECT_PATH", "CI_SERVER_URL", "CI_COMMIT_SHA"},
+		Events: []EventRule{
+			on(EventPullRequest, "CI_PIPELINE_SOURCE", "merge_request_event", "external_pull_request_event"),
+			on(EventSchedule, "CI_PIPELINE_SOURCE", "schedule"),
+			on(EventManual, "CI_PIPELINE_SOURCE", "web", "chat"),
+			on(EventAPI, "CI_PIPELINE_SOURCE", "api", "trigger", "pipeline", "parent_pipeline"),
+			on(EventTag, "CI_COMMIT_TAG"),
+			on(EventPush, "CI_PIPELINE_SOURCE", "push"),
+		},
+	},
+	"GOCD": {
+		Meta: &Meta{
+			BuildNumber: keys("GO_PIPELINE_COUNTER"),
+			BuildID:     keys("GO_PIPELINE_LABEL"),
+			JobID:       keys("GO_JOB_NAME"),
+			BuildURL:    tmpl("${GO_SERVER_URL}/pipelines/${GO_PIPELINE_NAME}/${GO_PIPELINE_COUNTER}/${GO_STAGE_NAME}/${GO_STAGE_COUNTER}"),
+		},
+		Corroborate: []string{"GO_PIPELINE_NAME", "GO_PIPELINE_COUNTER", "GO_SERVER_URL"},
+	},
+	"GOOGLE_CLOUD_BUILD": {
+		Meta: &Meta{
+			Commit:  keys("COMMIT_SHA"),
+			Branch:  keys("BRANCH_NAME"),
+			Tag:     keys("TAG_NAME"),
+			BuildID: keys("BUILD_ID"),
+			Repo:    keys("REPO_NAME"),
+		},
+	},
+	"HARNESS": {
+		Meta: &Meta{
+			Commit:      keys("DRONE_COMMIT_SHA"),
+			Branch:      keys("DRONE_SOURCE_BRANCH", "DRONE_BRANCH"),
+			Tag:         keys("DRONE_TAG"),
+			BuildNumber: keys("HARNESS_BUILD_ID"),
+			BuildID:     keys("HARNESS_BUILD_ID"),
+			Repo:        keys("DRONE_REPO"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("DRONE_PULL_REQUEST"),
+			SourceBranch: keys("DRONE_SOURCE_BRANCH"),
+			TargetBranch: keys("DRONE_TARGET_BRANCH"),
+			HeadSHA:      keys("DRONE_COMMIT_SHA"),
+		},
+		Events: droneEvents,
+	},
+	"HEROKU": {
+		Meta: &Meta{
+			Commit:  keys("HEROKU_TEST_RUN_COMMIT_VERSION", "SOURCE_VERSION"),
+			Branch:  keys("HEROKU_TEST_RUN_BRANCH"),
+			BuildID: keys("HEROKU_TEST_RUN_ID"),
+		},
+	},
+	"HUDSON": {
+		Meta: &Meta{
+			Commit:      keys("GIT_COMMIT"),
+			Branch:      syntax.Extract{{Env: "GIT_BRANCH", TrimPrefix: "origin/"}},
+			BuildNumber: keys("BUILD_NUMBER"),
+			BuildID:     keys("BUILD_ID"),
+			BuildURL:    keys("BUILD_URL"),
+		},
+		Corroborate: []string{"JOB_NAME", "BUILD_NUMBER", "BUILD_URL"},
+	},
+	"JENKINS": {
+		Meta: &Meta{
+			Commit: keys("ghprbActualCommit", "GIT_COMMIT"),
+			Branch: syntax.Extract{
+				{Env: "CHANGE_BRANCH"},
+				{Env: "ghprbSourceBranch"},
+				{Env: "BRANCH_NAME"},
+				{Env: "GIT_LOCAL_BRANCH"},
+				{Env: "GIT_BRANCH", TrimPrefix: "origin/"},
+			},
+			Tag:         keys("TAG_NAME"),
+			BuildNumber: keys("BUILD_NUMBER"),
+			BuildID:     keys("BUILD_ID"),
+			JobID:       keys("JOB_NAME"),
+			BuildURL:    keys("BUILD_URL"),
+			JobURL:      keys("JOB_URL"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CHANGE_ID", "ghprbPullId"),
+			SourceBranch: keys("CHANGE_BRANCH", "ghprbSourceBranch"),
+			TargetBranch: keys("CHANGE_TARGET", "ghprbTargetBranch"),
+			HeadSHA:      keys("ghprbActualCommit", "GIT_COMMIT"),
+		},
+		Corroborate: []string{"JOB_NAME", "BUILD_NUMBER", "BUILD_URL", "WORKSPACE"},
+		Events: []EventRule{
+			on(EventPullRequest, "CHANGE_ID"),
+			on(EventPullRequest, "ghprbPullId"),
+			on(EventTag, "TAG_NAME"),
+		},
+	},
+	"LAYERCI": {
+		Meta: &Meta{
+			Commit: keys("GIT_COMMIT"),
+			Branch: keys("LAYERCI_BRANCH"),
+			JobID:  keys("LAYERCI_JOB_ID"),
+			Repo:   tmpl("${LAYERCI_REPO_OWNER}/${LAYERCI_REPO_NAME}"),
+		},
+		PullRequest: &PullRequest{
+			Number: keys("LAYERCI_PULL_REQUEST"),
+		},
+	},
+	"MAGNUM": {
+		Meta: &Meta{
+			Commit:      keys("CI_COMMIT"),
+			Branch:      keys("CI_BRANCH"),
+			BuildNumber: keys("CI_BUILD_NUMBER"),
+			BuildURL:    keys("CI_BUILD_URL"),
+		},
+	},
+	"NETLIFY": {
+		Meta: &Meta{
+			Commit:  keys("COMMIT_REF"),
+			Branch:  keys("HEAD", "BRANCH"),
+			BuildID: keys("BUILD_ID"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("REVIEW_ID"),
+			SourceBranch: keys("HEAD"),
+			HeadSHA:      keys("COMMIT_REF"),
+		},
+		Corroborate: []string{"BUILD_ID", "COMMIT_REF", "SITE_ID"},
+	},
+	"NEVERCODE": {
+		Meta: &Meta{
+			Commit:      keys("NEVERCODE_COMMIT"),
+			Branch:      keys("NEVERCODE_BRANCH"),
+			BuildNumber: keys("NEVERCODE_BUILD_NUMBER"),
+		},
+	},
+	"PROW": {
+		Meta: &Meta{
+			Commit:  keys("PULL_PULL_SHA", "PULL_BASE_SHA"),
+			Branch:  keys("PULL_BASE_REF"),
+			BuildID: keys("BUILD_ID"),
+			JobID:   keys("PROW_JOB_ID"),
+			Repo:    tmpl("${REPO_OWNER}/${REPO_NAME}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("PULL_NUMBER"),
+			TargetBranch: keys("PULL_BASE_REF"),
+			HeadSHA:      keys("PULL_PULL_SHA"),
+			BaseSHA:      keys("PULL_BASE_SHA"),
+		},
+		Corroborate: []string{"JOB_NAME", "JOB_TYPE", "BUILD_ID"},
+	},
+	"RELEASEHUB": {
+		Meta: &Meta{
+			Commit:  keys("RELEASE_COMMIT_SHA"),
+			Branch:  keys("RELEASE_BRANCH"),
+			BuildID: keys("RELEASE_BUILD_ID"),
+		},
+	},
+	"RENDER": {
+		Meta: &Meta{
+			Commit: keys("RENDER_GIT_COMMIT"),
+			Branch: keys("RENDER_GIT_BRANCH"),
+			Repo:   keys("RENDER_GIT_REPO_SLUG"),
+		},
+		Corroborate: []string{"RENDER_SERVICE_ID", "RENDER_GIT_COMMIT"},
+	},
+	"SAIL": {
+		Meta: &Meta{
+			Commit: keys("SAIL_COMMIT_SHA"),
+			Branch: keys("SAIL_COMMIT_BRANCH"),
+			Repo:   tmpl("${SAIL_REPO_OWNER}/${SAIL_REPO_NAME}"),
+		},
+		PullRequest: &PullRequest{
+			Number: keys("SAIL_PULL_REQUEST_NUMBER"),
+		},
+	},
+	"SCREWDRIVER": {
+		Meta: &Meta{
+			Commit:  keys("SD_BUILD_SHA"),
+			Branch:  keys("GIT_BRANCH"),
+			BuildID: keys("SD_BUILD_ID"),
+			JobID:   keys("SD_JOB_ID"),
+		},
+		PullRequest: &PullRequest{
+			Number: keys("SD_PULL_REQUEST"),
+		},
+		Corroborate: []string{"SD_BUILD_ID", "SD_JOB_ID", "SD_PIPELINE_ID"},
+	},
+	"SEMAPHORE": {
+		Meta: &Meta{
+			Commit:   keys("SEMAPHORE_GIT_SHA"),
+			Branch:   keys("SEMAPHORE_GIT_PR_BRANCH", "SEMAPHORE_GIT_BRANCH"),
+			Tag:      keys("SEMAPHORE_GIT_TAG_NAME"),
+			BuildID:  keys("SEMAPHORE_WORKFLOW_ID"),
+			JobID:    keys("SEMAPHORE_JOB_ID"),
+			BuildURL: tmpl("${SEMAPHORE_ORGANIZATION_URL}/workflows/${SEMAPHORE_WORKFLOW_ID}"),
+			JobURL:   tmpl("${SEMAPHORE_ORGANIZATION_URL}/jobs/${SEMAPHORE_JOB_ID}"),
+			Repo:     keys("SEMAPHORE_GIT_REPO_SLUG"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("SEMAPHORE_GIT_PR_NUMBER", "PULL_REQUEST_NUMBER"),
+			SourceBranch: keys("SEMAPHORE_GIT_PR_BRANCH"),
+			TargetBranch: keys("SEMAPHORE_GIT_BRANCH"),
+			HeadSHA:      keys("SEMAPHORE_GIT_PR_SHA"),
+		},
+		Corroborate: []string{"SEMAPHORE_WORKFLOW_ID", "SEMAPHORE_JOB_ID", "SEMAPHORE_GIT_SHA"},
+		Events: []EventRule{
+			on(EventSchedule, "SEMAPHORE_WORKFLOW_TRIGGERED_BY_SCHEDULE", "true"),
+			on(EventAPI, "SEMAPHORE_WORKFLOW_TRIGGERED_BY_API", "true"),
+			on(EventManual, "SEMAPHORE_WORKFLOW_TRIGGERED_BY_MANUAL_RUN", "true"),
+			on(EventPullRequest, "SEMAPHORE_GIT_REF_TYPE", "pull-request"),
+			on(EventTag, "SEMAPHORE_GIT_REF_TYPE", "tag"),
+			on(EventPush, "SEMAPHORE_GIT_REF_TYPE", "branch"),
+		},
+	},
+	"SOURCEHUT": {
+		Meta: &Meta{
+			JobID:  keys("JOB_ID"),
+			JobURL: keys("JOB_URL"),
+		},
+		Corroborate: []string{"JOB_ID", "JOB_URL"},
+	},
+	"TASKCLUSTER": {
+		Meta: &Meta{
+			BuildID: keys("TASK_GROUP_ID"),
+			JobID:   keys("TASK_ID"),
+			JobURL:  tmpl("${TASKCLUSTER_ROOT_URL}/tasks/${TASK_ID}"),
+		},
+		Corroborate: []string{"TASKCLUSTER_ROOT_URL", "TASK_GROUP_ID"},
+	},
+	"TEAMCITY": {
+		Meta: &Meta{
+			Commit:      keys("BUILD_VCS_NUMBER"),
+			BuildNumber: keys("BUILD_NUMBER"),
+		},
+		Corroborate: []string{"BUILD_NUMBER", "TEAMCITY_PROJECT_NAME", "TEAMCITY_BUILDCONF_NAME"},
+	},
+	"TRAVIS": {
+		Meta: &Meta{
+			Commit:      keys("TRAVIS_PULL_REQUEST_SHA", "TRAVIS_COMMIT"),
+			Branch:      keys("TRAVIS_PULL_REQUEST_BRANCH", "TRAVIS_BRANCH"),
+			Tag:         keys("TRAVIS_TAG"),
+			BuildNumber: keys("TRAVIS_BUILD_NUMBER"),
+			BuildID:     keys("TRAVIS_BUILD_ID"),
+			JobID:       keys("TRAVIS_JOB_ID"),
+			BuildURL:    keys("TRAVIS_BUILD_WEB_URL"),
+			JobURL:      keys("TRAVIS_JOB_WEB_URL"),
+			Repo:        keys("TRAVIS_REPO_SLUG"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("TRAVIS_PULL_REQUEST"),
+			SourceBranch: keys("TRAVIS_PULL_REQUEST_BRANCH"),
+			TargetBranch: keys("TRAVIS_BRANCH"),
+			HeadSHA:      keys("TRAVIS_PULL_REQUEST_SHA"),
+		},
+		Corroborate: []string{"TRAVIS_BUILD_ID", "TRAVIS_JOB_ID", "TRAVIS_REPO_SLUG", "TRAVIS_COMMIT"},
+		Events: []EventRule{
+			on(EventPush, "TRAVIS_EVENT_TYPE", "push"),
+			on(EventPullRequest, "TRAVIS_EVENT_TYPE", "pull_request"),
+			on(EventSchedule, "TRAVIS_EVENT_TYPE", "cron"),
+			on(EventAPI, "TRAVIS_EVENT_TYPE", "api"),
+		},
+	},
+	"VELA": {
+		Meta: &Meta{
+			Commit:      keys("VELA_BUILD_COMMIT"),
+			Branch:      keys("VELA_BUILD_BRANCH"),
+			Tag:         keys("VELA_BUILD_TAG"),
+			BuildNumber: keys("VELA_BUILD_NUMBER"),
+			BuildURL:    keys("VELA_BUILD_LINK"),
+			Repo:        keys("VELA_REPO_FULL_NAME"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("VELA_BUILD_PULL_REQUEST"),
+			SourceBranch: keys("VELA_PULL_REQUEST_SOURCE"),
+			TargetBranch: keys("VELA_PULL_REQUEST_TARGET"),
+			HeadSHA:      keys("VELA_BUILD_COMMIT"),
+		},
+		Corroborate: []string{"VELA_BUILD_NUMBER", "VELA_REPO_FULL_NAME"},
+		Events: []EventRule{
+			on(EventPush, "VELA_BUILD_EVENT", "push"),
+			on(EventPullRequest, "VELA_BUILD_EVENT", "pull_request"),
+			on(EventTag, "VELA_BUILD_EVENT", "tag"),
+			on(EventSchedule, "VELA_BUILD_EVENT", "schedule"),
+			on(EventAPI, "VELA_BUILD_EVENT", "deployment"),
+		},
+	},
+	"VERCEL": {
+		Meta: &Meta{
+			Commit:  keys("VERCEL_GIT_COMMIT_SHA"),
+			Branch:  keys("VERCEL_GIT_COMMIT_REF"),
+			BuildID: keys("VERCEL_DEPLOYMENT_ID"),
+			Repo:    tmpl("${VERCEL_GIT_REPO_OWNER}/${VERCEL_GIT_REPO_SLUG}"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("VERCEL_GIT_PULL_REQUEST_ID"),
+			SourceBranch: keys("VERCEL_GIT_COMMIT_REF"),
+			HeadSHA:      keys("VERCEL_GIT_COMMIT_SHA"),
+		},
+		Corroborate: []string{"VERCEL_ENV", "VERCEL_URL", "VERCEL_GIT_COMMIT_SHA"},
+	},
+	"APPCENTER": {
+		Meta: &Meta{
+			Branch:  keys("APPCENTER_BRANCH"),
+			BuildID: keys("APPCENTER_BUILD_ID"),
+		},
+		Corroborate: []string{"APPCENTER_SOURCE_DIRECTORY", "APPCENTER_BRANCH"},
+	},
+	"WOODPECKER": {
+		Meta: &Meta{
+			Commit:      keys("CI_COMMIT_SHA"),
+			Branch:      keys("CI_COMMIT_SOURCE_BRANCH", "CI_COMMIT_BRANCH"),
+			Tag:         keys("CI_COMMIT_TAG"),
+			BuildNumber: keys("CI_PIPELINE_NUMBER"),
+			BuildURL:    keys("CI_PIPELINE_URL"),
+			Repo:        keys("CI_REPO"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CI_COMMIT_PULL_REQUEST"),
+			SourceBranch: keys("CI_COMMIT_SOURCE_BRANCH"),
+			TargetBranch: keys("CI_COMMIT_TARGET_BRANCH"),
+			HeadSHA:      keys("CI_COMMIT_SHA"),
+		},
+		Corroborate: []string{"CI_PIPELINE_NUMBER", "CI_REPO", "CI_COMMIT_SHA"},
+		Events: []EventRule{
+			on(EventPush, "CI_PIPELINE_EVENT", "push"),
+			on(EventPullRequest, "CI_PIPELINE_EVENT", "pull_request"),
+			on(EventTag, "CI_PIPELINE_EVENT", "tag"),
+			on(EventSchedule, "CI_PIPELINE_EVENT", "cron"),
+			on(EventManual, "CI_PIPELINE_EVENT", "manual"),
+			on(EventAPI, "CI_PIPELINE_EVENT", "deployment"),
+		},
+	},
+	"XCODE_CLOUD": {
+		Meta: &Meta{
+			Commit:      keys("CI_COMMIT"),
+			Branch:      keys("CI_BRANCH"),
+			Tag:         keys("CI_TAG"),
+			BuildNumber: keys("CI_BUILD_NUMBER"),
+			BuildID:     keys("CI_BUILD_ID"),
+			BuildURL:    keys("CI_BUILD_URL"),
+		},
+		PullRequest: &PullRequest{
+			Number:       keys("CI_PULL_REQUEST_NUMBER"),
+			SourceBranch: keys("CI_PULL_REQUEST_SOURCE_BRANCH"),
+			TargetBranch: keys("CI_PULL_REQUEST_TARGET_BRANCH"),
+			HeadSHA:      keys("CI_PULL_REQUEST_SOURCE_COMMIT"),
+			BaseSHA:      keys("CI_PULL_REQUEST_TARGET_COMMIT"),
+		},
+		Corroborate: []string{"CI_BUILD_ID", "CI_WORKFLOW", "CI_PRODUCT"},
+	},
+	"XCODE_SERVER": {
+		Meta: &Meta{
+			BuildNumber: keys("XCS_INTEGRATION_NUMBER"),
+			BuildID:     keys("XCS_INTEGRATION_ID"),
+		},
+	},
+}
+
+var githubMeta = &Meta{
+	Commit: keys("GITHUB_SHA"),
+	Branch: syntax.Extract{
+		{Env: "GITHUB_HEAD_REF"},
+		{Env: "GITHUB_REF_NAME", If: when("GITHUB_REF_TYPE", "branch")},
+	},
+	Tag:         syntax.Extract{{Env: "GITHUB_REF_NAME", If: when("GITHUB_REF_TYPE", "tag")}},
+	BuildNumber: keys("GITHUB_RUN_NUMBER"),
+	BuildID:     keys("GITHUB_RUN_ID"),
+	JobID:       keys("GITHUB_JOB"),
+	BuildURL:    tmpl("${GITHUB_SERVER_URL}/${GITHUB_REPOSITORY}/actions/runs/${GITHUB_RUN_ID}"),
+	Repo:        keys("GITHUB_REPOSITORY"),
+}
+
+var githubPullRequest = &PullRequest{
+	Number:       syntax.Extract{{Env: "GITHUB_REF", TrimPrefix: "refs/pull/"}},
+	SourceBranch: keys("GITHUB_HEAD_REF"),
+	TargetBranch: keys("GITHUB_BASE_REF"),
+}
+
+// githubPR agrees with githubEvents on which events are pull requests.
+var githubPR = &syntax.PR{StrictEqual: "GITHUB_EVENT_NAME", EqualsAnyOf: []string{"pull_request", "pull_request_target"}}
+
+var githubEvents = []EventRule{
+	on(EventPush, "GITHUB_EVENT_NAME", "push"),
+	on(EventPullRequest, "GITHUB_EVENT_NAME", "pull_request", "pull_request_target"),
+	on(EventSchedule, "GITHUB_EVENT_NAME", "schedule"),
+	on(EventManual, "GITHUB_EVENT_NAME", "workflow_dispatch"),
+	on(EventMergeQueue, "GITHUB_EVENT_NAME", "merge_group"),
+	on(EventAPI, "GITHUB_EVENT_NAME", "repository_dispatch"),
+}
+
+var droneEvents = []EventRule{
+	on(EventPush, "DRONE_BUILD_EVENT", "push"),
+	on(EventPullRequest, "DRONE_BUILD_EVENT", "pull_request"),
+	on(EventTag, "DRONE_BUILD_EVENT", "tag"),
+	on(EventSchedule, "DRONE_BUILD_EVENT", "cron"),
+	on(EventManual, "DRONE_BUILD_EVENT", "promote", "rollback"),
+	on(EventAPI, "DRONE_BUILD_EVENT", "custom"),
+}
+
+func extend(v Vendor) Vendor {
+	x, ok := extras[v.Constant]
+	if !ok {
+		return v
+	}
+	if x.PR != nil {
+		v.PR = x.PR
+	}
+	if v.Meta == nil {
+		v.Meta = x.Meta
+	}
+	if v.PullRequest == nil {
+		v.PullRequest = x.PullRequest
+	}
+	if v.NestedIn == nil {
+		v.NestedIn = x.NestedIn
+	}
+	if v.Corroborate == nil {
+		v.Corroborate = x.Corroborate
+	}
+	if v.Events == nil {
+		v.Events = x.Events
+	}
+	return v
+}
+
+func keys(ks ...string) syntax.Extract {
+	x := make(syntax.Extract, len(ks))
+	for i, k := range ks {
+		x[i].Env = k
+	}
+	return x
+}
+
+func tmpl(template string) syntax.Extract {
+	return syntax.Extract{{Template: template}}
+}
+
+func ref(key, prefix string) syntax.Source {
+	return syntax.Source{
+		Env:        key,
+		TrimPrefix: prefix,
+		If:         syntax.EnvList{{StrictEqual: key, Predicates: syntax.Predicates{Prefix: prefix}}},
+	}
+}
+
+// on classifies the build as event when key is set to one of values, or
+// when key is set at all if no values are given.
+func on(event Event, key string, values ...string) EventRule {
+	return EventRule{Event: event, When: syntax.PR{StrictEqual: key, EqualsAnyOf: values}}
+}
+
+func when(key, value string) syntax.EnvList {
+	return syntax.EnvList{{EqualsMap: map[string]string{key: value}}}
+}
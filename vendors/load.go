@@ -0,0 +1,198 @@
+package vendors
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+// JSON is the ci-info vendors.json that All was generated from.
+//
+//go:embed vendors.json
+var JSON []byte
+
+// Load decodes a vendor list in the ci-info vendors.json format. Vendors
+// known to this package get the same metadata, pull request, nesting and
+// event rules as their counterparts in All unless the input defines them.
+func Load(r io.Reader) ([]Vendor, error) {
+	var vs []Vendor
+	if err := json.NewDecoder(r).Decode(&vs); err != nil {
+		return nil, fmt.Errorf("vendors: %w", err)
+	}
+	for i := range vs {
+		vs[i] = extend(vs[i])
+	}
+	return vs, nil
+}
+
+func LoadFile(name string) ([]Vendor, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Embedded decodes JSON.
+func Embedded() ([]Vendor, error) {
+	return Load(bytes.NewReader(JSON))
+}
+
+// LoadStrict is like Load but rejects unknown keys, wrongly typed values and
+// empty rules, with a *syntax.DecodeError whose path locates the problem,
+// such as "vendors[12].pr.any[1]".
+func LoadStrict(r io.Reader) ([]Vendor, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, &syntax.DecodeError{Path: "vendors", Msg: "invalid JSON"}
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, &syntax.DecodeError{Path: "vendors", Msg: "expected array"}
+	}
+	for i, raw := range raws {
+		if err := checkVendor("vendors["+strconv.Itoa(i)+"]", raw); err != nil {
+			return nil, err
+		}
+	}
+	return Load(bytes.NewReader(data))
+}
+
+func LoadFileStrict(name string) ([]Vendor, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadStrict(f)
+}
+
+func checkVendor(path string, data []byte) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return &syntax.DecodeError{Path: path, Msg: "expected object"}
+	}
+	for _, k := range []string{"name", "constant", "env"} {
+		if _, ok := obj[k]; !ok {
+			return &syntax.DecodeError{Path: syntax.JoinPath(path, k), Msg: "missing"}
+		}
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		keyPath := syntax.JoinPath(path, k)
+		v := obj[k]
+		var err error
+		switch k {
+		case "name", "constant":
+			var s string
+			if json.Unmarshal(v, &s) != nil || s == "" || v[0] != '"' {
+				err = &syntax.DecodeError{Path: keyPath, Msg: "expected non-empty string"}
+			}
+		case "env":
+			err = strictRule(keyPath, v, new(syntax.EnvList))
+		case "pr":
+			err = strictRule(keyPath, v, new(syntax.PR))
+		case "nestedIn", "corroborate":
+			err = checkStrings(keyPath, v)
+		case "meta":
+			err = checkExtracts(keyPath, v, "commit", "branch", "tag", "buildNumber", "buildID", "jobID", "buildURL", "jobURL", "repo")
+		case "pullRequest":
+			err = checkExtracts(keyPath, v, "number", "sourceBranch", "targetBranch", "headSHA", "baseSHA")
+		case "events":
+			err = checkEvents(keyPath, v)
+		default:
+			err = &syntax.DecodeError{Path: keyPath, Msg: "unknown key"}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// strictRule decodes data with syntax.UnmarshalStrict and places its errors
+// under path.
+func strictRule(path string, data []byte, v any) error {
+	err := syntax.UnmarshalStrict(data, v)
+	if de, ok := err.(*syntax.DecodeError); ok {
+		return &syntax.DecodeError{Path: syntax.JoinPath(path, de.Path), Msg: de.Msg}
+	}
+	return err
+}
+
+func checkStrings(path string, data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil || data[0] != '[' {
+		return &syntax.DecodeError{Path: path, Msg: "expected array"}
+	}
+	for i, raw := range raws {
+		if raw[0] != '"' {
+			return &syntax.DecodeError{Path: syntax.JoinPath(path, "["+strconv.Itoa(i)+"]"), Msg: "expected string"}
+		}
+	}
+	return nil
+}
+
+func checkExtracts(path string, data []byte, fields ...string) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return &syntax.DecodeError{Path: path, Msg: "expected object"}
+	}
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		keyPath := syntax.JoinPath(path, k)
+		if !slices.Contains(fields, k) {
+			return &syntax.DecodeError{Path: keyPath, Msg: "unknown key"}
+		}
+		if err := strictRule(keyPath, obj[k], new(syntax.Extract)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkEvents(path string, data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil || data[0] != '[' {
+		return &syntax.DecodeError{Path: path, Msg: "expected array"}
+	}
+	for i, raw := range raws {
+		rulePath := syntax.JoinPath(path, "["+strconv.Itoa(i)+"]")
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+			return &syntax.DecodeError{Path: rulePath, Msg: "expected object"}
+		}
+		for _, k := range []string{"event", "when"} {
+			if _, ok := obj[k]; !ok {
+				return &syntax.DecodeError{Path: syntax.JoinPath(rulePath, k), Msg: "missing"}
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(obj)) {
+			keyPath := syntax.JoinPath(rulePath, k)
+			switch k {
+			case "event":
+				if obj[k][0] != '"' {
+					return &syntax.DecodeError{Path: keyPath, Msg: "expected string"}
+				}
+			case "when":
+				if err := strictRule(keyPath, obj[k], new(syntax.PR)); err != nil {
+					return err
+				}
+			default:
+				return &syntax.DecodeError{Path: keyPath, Msg: "unknown key"}
+			}
+		}
+	}
+	return nil
+}
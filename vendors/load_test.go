@@ -0,0 +1,102 @@
+package vendors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func TestEmbeddedMatchesAll(t *testing.T) {
+	vs, err := Embedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vs, All) {
+		t.Error("embedded vendors.json does not match the generated vendors; run go generate ./gen")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "vendors.json")
+	data := `[
+		{"name": "Example CI", "constant": "EXAMPLE", "env": {"env": "EXAMPLE_CI", "includes": "yes"}, "pr": "EXAMPLE_PR"},
+		{"name": "GitHub Actions", "constant": "GITHUB_ACTIONS", "env": "GITHUB_ACTIONS"}
+	]`
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	vs, err := LoadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) != 2 {
+		t.Fatalf("got %d vendors, want 2", len(vs))
+	}
+	if !vs[0].Env.Match(syntax.Map{"EXAMPLE_CI": "oh yes"}) || vs[0].PR == nil {
+		t.Errorf("unexpected vendor %+v", vs[0])
+	}
+	if vs[1].Meta == nil || vs[1].Events == nil {
+		t.Error("known vendor did not get its extras")
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	if _, err := Load(strings.NewReader(`[{"env": 1}]`)); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestLoadStrict(t *testing.T) {
+	vs, err := LoadStrict(bytes.NewReader(JSON))
+	if err != nil {
+		t.Fatalf("embedded vendors.json: %v", err)
+	}
+	if !reflect.DeepEqual(vs, All) {
+		t.Error("strict load differs from All")
+	}
+
+	data, err := json.Marshal(All)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadStrict(bytes.NewReader(data)); err != nil {
+		t.Errorf("marshalled All: %v", err)
+	}
+
+	tests := []struct {
+		data string
+		want syntax.DecodeError
+	}{
+		{`{}`, syntax.DecodeError{Path: "vendors", Msg: "expected array"}},
+		{`[1]`, syntax.DecodeError{Path: "vendors[0]", Msg: "expected object"}},
+		{`[{"name":"A","constant":"A"}]`, syntax.DecodeError{Path: "vendors[0].env", Msg: "missing"}},
+		{`[{"name":"A","constant":"A","env":"A","nmae":"x"}]`, syntax.DecodeError{Path: "vendors[0].nmae", Msg: "unknown key"}},
+		{`[{"name":"","constant":"A","env":"A"}]`, syntax.DecodeError{Path: "vendors[0].name", Msg: "expected non-empty string"}},
+		{`[{"name":"A","constant":"A","env":"A"},{"name":"B","constant":"B","env":"B","pr":{"any":["X",1]}}]`, syntax.DecodeError{Path: "vendors[1].pr.any[1]", Msg: "expected string"}},
+		{`[{"name":"A","constant":"A","env":["A",{}]}]`, syntax.DecodeError{Path: "vendors[0].env[1]", Msg: "empty rule"}},
+		{`[{"name":"A","constant":"A","env":"A","meta":{"commit":[{"env":1}]}}]`, syntax.DecodeError{Path: "vendors[0].meta.commit[0].env", Msg: "expected string"}},
+		{`[{"name":"A","constant":"A","env":"A","meta":{"sha":[]}}]`, syntax.DecodeError{Path: "vendors[0].meta.sha", Msg: "unknown key"}},
+		{`[{"name":"A","constant":"A","env":"A","events":[{"event":"push","when":{"X":true}}]}]`, syntax.DecodeError{Path: "vendors[0].events[0].when.X", Msg: "expected string"}},
+		{`[{"name":"A","constant":"A","env":"A","nestedIn":[1]}]`, syntax.DecodeError{Path: "vendors[0].nestedIn[0]", Msg: "expected string"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			_, err := LoadStrict(strings.NewReader(tt.data))
+			var got *syntax.DecodeError
+			if !errors.As(err, &got) || *got != tt.want {
+				t.Errorf("error = %v, want %v", err, &tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package vendors
+
+import "slices"
+
+// AnyVendor in Vendor.NestedIn declares that a vendor usually runs inside
+// whichever other vendor is detected alongside it.
+const AnyVendor = "*"
+
+func (v *Vendor) nestedIn(outer *Vendor) bool {
+	if v.Constant == outer.Constant {
+		return false
+	}
+	if slices.Contains(v.NestedIn, outer.Constant) {
+		return true
+	}
+	// a wildcard never claims to be inside another wildcard or inside a
+	// vendor that explicitly declares itself nested in v
+	return slices.Contains(v.NestedIn, AnyVendor) &&
+		!slices.Contains(outer.NestedIn, AnyVendor) &&
+		!slices.Contains(outer.NestedIn, v.Constant)
+}
+
+// SortByNesting orders vendors detected together from the outermost
+// orchestrator to the innermost build tool according to their NestedIn
+// declarations. Vendors with no relation between them keep their relative
+// order, and declarations that form a cycle are ignored.
+func SortByNesting(vs []Vendor) []Vendor {
+	n := len(vs)
+	indegree := make([]int, n)
+	for i := range vs {
+		for j := range vs {
+			if vs[i].nestedIn(&vs[j]) {
+				indegree[i]++
+			}
+		}
+	}
+
+	out := make([]Vendor, 0, n)
+	done := make([]bool, n)
+	for len(out) < n {
+		next := -1
+		for i := range vs {
+			if !done[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// cycle: fall back to the first remaining vendor
+			next = slices.Index(done, false)
+		}
+
+		done[next] = true
+		out = append(out, vs[next])
+		for i := range vs {
+			if !done[i] && vs[i].nestedIn(&vs[next]) {
+				indegree[i]--
+			}
+		}
+	}
+	return out
+}
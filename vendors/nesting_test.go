@@ -0,0 +1,63 @@
+package vendors
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortByNesting(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []Vendor
+		want []string
+	}{
+		{
+			name: "no relation keeps order",
+			in:   []Vendor{{Constant: "A"}, {Constant: "B"}},
+			want: []string{"A", "B"},
+		},
+		{
+			name: "explicit outer moves first",
+			in: []Vendor{
+				{Constant: "INNER", NestedIn: []string{"OUTER"}},
+				{Constant: "OUTER"},
+			},
+			want: []string{"OUTER", "INNER"},
+		},
+		{
+			name: "wildcard goes inside everything",
+			in: []Vendor{
+				{Constant: "TOOL", NestedIn: []string{AnyVendor}},
+				{Constant: "A"},
+				{Constant: "B"},
+			},
+			want: []string{"A", "B", "TOOL"},
+		},
+		{
+			name: "three levels",
+			in: []Vendor{
+				{Constant: "TOOL", NestedIn: []string{AnyVendor}},
+				{Constant: "GERRIT", NestedIn: []string{"JENKINS"}},
+				{Constant: "JENKINS"},
+			},
+			want: []string{"JENKINS", "GERRIT", "TOOL"},
+		},
+		{
+			name: "cycle falls back to order",
+			in: []Vendor{
+				{Constant: "A", NestedIn: []string{"B"}},
+				{Constant: "B", NestedIn: []string{"A"}},
+			},
+			want: []string{"A", "B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := constants(SortByNesting(tt.in))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortByNesting = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
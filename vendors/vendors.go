@@ -1,10 +1,57 @@
 package vendors
 
-import "github.com/startracex/ciinfo/syntax"
+import (
+	"slices"
+
+	"github.com/startracex/ciinfo/syntax"
+)
 
 type Vendor struct {
-	Name     string         `json:"name"`
-	Constant string         `json:"constant"`
-	Env      syntax.EnvList `json:"env"`
-	PR       *syntax.PR     `json:"pr"`
+	Name        string         `json:"name"`
+	Constant    string         `json:"constant"`
+	Env         syntax.EnvList `json:"env"`
+	PR          *syntax.PR     `json:"pr,omitempty"`
+	Meta        *Meta          `json:"meta,omitempty"`
+	PullRequest *PullRequest   `json:"pullRequest,omitempty"`
+	NestedIn    []string       `json:"nestedIn,omitempty"`
+	Corroborate []string       `json:"corroborate,omitempty"`
+	Events      []EventRule    `json:"events,omitempty"`
+}
+
+type Meta struct {
+	Commit      syntax.Extract `json:"commit,omitempty"`
+	Branch      syntax.Extract `json:"branch,omitempty"`
+	Tag         syntax.Extract `json:"tag,omitempty"`
+	BuildNumber syntax.Extract `json:"buildNumber,omitempty"`
+	BuildID     syntax.Extract `json:"buildID,omitempty"`
+	JobID       syntax.Extract `json:"jobID,omitempty"`
+	BuildURL    syntax.Extract `json:"buildURL,omitempty"`
+	JobURL      syntax.Extract `json:"jobURL,omitempty"`
+	Repo        syntax.Extract `json:"repo,omitempty"`
+}
+
+type PullRequest struct {
+	Number       syntax.Extract `json:"number,omitempty"`
+	SourceBranch syntax.Extract `json:"sourceBranch,omitempty"`
+	TargetBranch syntax.Extract `json:"targetBranch,omitempty"`
+	HeadSHA      syntax.Extract `json:"headSHA,omitempty"`
+	BaseSHA      syntax.Extract `json:"baseSHA,omitempty"`
+}
+
+// CommonKeys returns the variables set by many CI services. When no vendor
+// matches, any of them being set still means CI.
+func CommonKeys() []string {
+	return slices.Clone(commonKeys)
+}
+
+var commonKeys = []string{
+	"BUILD_ID",
+	"BUILD_NUMBER",
+	"CI",
+	"CI_APP_ID",
+	"CI_BUILD_ID",
+	"CI_BUILD_NUMBER",
+	"CI_NAME",
+	"CONTINUOUS_INTEGRATION",
+	"RUN_ID",
 }
@@ -6,58 +6,58 @@ import (
 )
 
 var (
-	VendorAGOLA              = Vendor{Name: "Agola CI", Constant: "AGOLA", Env: syntax.EnvList{{StrictEqual: "AGOLA_GIT_REF", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "AGOLA_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorALPIC              = Vendor{Name: "Alpic", Constant: "ALPIC", Env: syntax.EnvList{{StrictEqual: "ALPIC_HOST", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorAPPCIRCLE          = Vendor{Name: "Appcircle", Constant: "APPCIRCLE", Env: syntax.EnvList{{StrictEqual: "AC_APPCIRCLE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "AC_GIT_PR", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorAPPVEYOR           = Vendor{Name: "AppVeyor", Constant: "APPVEYOR", Env: syntax.EnvList{{StrictEqual: "APPVEYOR", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "APPVEYOR_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorCODEBUILD          = Vendor{Name: "AWS CodeBuild", Constant: "CODEBUILD", Env: syntax.EnvList{{StrictEqual: "CODEBUILD_BUILD_ARN", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CODEBUILD_WEBHOOK_EVENT", NotEqual: "", EqualsAnyOf: []string{"PULL_REQUEST_CREATED", "PULL_REQUEST_UPDATED", "PULL_REQUEST_REOPENED"}, EqualsMap: map[string]string(nil)}}
-	VendorAZURE_PIPELINES    = Vendor{Name: "Azure Pipelines", Constant: "AZURE_PIPELINES", Env: syntax.EnvList{{StrictEqual: "TF_BUILD", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"BUILD_REASON": "PullRequest"}}}
-	VendorBAMBOO             = Vendor{Name: "Bamboo", Constant: "BAMBOO", Env: syntax.EnvList{{StrictEqual: "bamboo_planKey", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorBITBUCKET          = Vendor{Name: "Bitbucket Pipelines", Constant: "BITBUCKET", Env: syntax.EnvList{{StrictEqual: "BITBUCKET_COMMIT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BITBUCKET_PR_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorBITRISE            = Vendor{Name: "Bitrise", Constant: "BITRISE", Env: syntax.EnvList{{StrictEqual: "BITRISE_IO", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BITRISE_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorBUDDY              = Vendor{Name: "Buddy", Constant: "BUDDY", Env: syntax.EnvList{{StrictEqual: "BUDDY_WORKSPACE_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BUDDY_EXECUTION_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorBUILDKITE          = Vendor{Name: "Buildkite", Constant: "BUILDKITE", Env: syntax.EnvList{{StrictEqual: "BUILDKITE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BUILDKITE_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorCIRCLE             = Vendor{Name: "CircleCI", Constant: "CIRCLE", Env: syntax.EnvList{{StrictEqual: "CIRCLECI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CIRCLE_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorCIRRUS             = Vendor{Name: "Cirrus CI", Constant: "CIRRUS", Env: syntax.EnvList{{StrictEqual: "CIRRUS_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CIRRUS_PR", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorCLOUDFLARE_PAGES   = Vendor{Name: "Cloudflare Pages", Constant: "CLOUDFLARE_PAGES", Env: syntax.EnvList{{StrictEqual: "CF_PAGES", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorCLOUDFLARE_WORKERS = Vendor{Name: "Cloudflare Workers", Constant: "CLOUDFLARE_WORKERS", Env: syntax.EnvList{{StrictEqual: "WORKERS_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorCODEFRESH          = Vendor{Name: "Codefresh", Constant: "CODEFRESH", Env: syntax.EnvList{{StrictEqual: "CF_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string{"CF_PULL_REQUEST_NUMBER", "CF_PULL_REQUEST_ID"}, EqualsMap: map[string]string(nil)}}
-	VendorCODEMAGIC          = Vendor{Name: "Codemagic", Constant: "CODEMAGIC", Env: syntax.EnvList{{StrictEqual: "CM_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CM_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorCODESHIP           = Vendor{Name: "Codeship", Constant: "CODESHIP", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_NAME": "codeship"}}}, PR: nil}
-	VendorDRONE              = Vendor{Name: "Drone", Constant: "DRONE", Env: syntax.EnvList{{StrictEqual: "DRONE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"DRONE_BUILD_EVENT": "pull_request"}}}
-	VendorDSARI              = Vendor{Name: "dsari", Constant: "DSARI", Env: syntax.EnvList{{StrictEqual: "DSARI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorEARTHLY            = Vendor{Name: "Earthly", Constant: "EARTHLY", Env: syntax.EnvList{{StrictEqual: "EARTHLY_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorEAS                = Vendor{Name: "Expo Application Services", Constant: "EAS", Env: syntax.EnvList{{StrictEqual: "EAS_BUILD", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorGERRIT             = Vendor{Name: "Gerrit", Constant: "GERRIT", Env: syntax.EnvList{{StrictEqual: "GERRIT_PROJECT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorGITEA_ACTIONS      = Vendor{Name: "Gitea Actions", Constant: "GITEA_ACTIONS", Env: syntax.EnvList{{StrictEqual: "GITEA_ACTIONS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorGITHUB_ACTIONS     = Vendor{Name: "GitHub Actions", Constant: "GITHUB_ACTIONS", Env: syntax.EnvList{{StrictEqual: "GITHUB_ACTIONS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"GITHUB_EVENT_NAME": "pull_request"}}}
-	VendorGITLAB             = Vendor{Name: "GitLab CI", Constant: "GITLAB", Env: syntax.EnvList{{StrictEqual: "GITLAB_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CI_MERGE_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorGOCD               = Vendor{Name: "GoCD", Constant: "GOCD", Env: syntax.EnvList{{StrictEqual: "GO_PIPELINE_LABEL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorGOOGLE_CLOUD_BUILD = Vendor{Name: "Google Cloud Build", Constant: "GOOGLE_CLOUD_BUILD", Env: syntax.EnvList{{StrictEqual: "BUILDER_OUTPUT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorHARNESS            = Vendor{Name: "Harness CI", Constant: "HARNESS", Env: syntax.EnvList{{StrictEqual: "HARNESS_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorHEROKU             = Vendor{Name: "Heroku", Constant: "HEROKU", Env: syntax.EnvList{{StrictEqual: "NODE", Includes: "/app/.heroku/node/bin/node", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorHUDSON             = Vendor{Name: "Hudson", Constant: "HUDSON", Env: syntax.EnvList{{StrictEqual: "HUDSON_URL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorJENKINS            = Vendor{Name: "Jenkins", Constant: "JENKINS", Env: syntax.EnvList{{StrictEqual: "JENKINS_URL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}, {StrictEqual: "BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string{"ghprbPullId", "CHANGE_ID"}, EqualsMap: map[string]string(nil)}}
-	VendorLAYERCI            = Vendor{Name: "LayerCI", Constant: "LAYERCI", Env: syntax.EnvList{{StrictEqual: "LAYERCI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "LAYERCI_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorMAGNUM             = Vendor{Name: "Magnum CI", Constant: "MAGNUM", Env: syntax.EnvList{{StrictEqual: "MAGNUM", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorNETLIFY            = Vendor{Name: "Netlify CI", Constant: "NETLIFY", Env: syntax.EnvList{{StrictEqual: "NETLIFY", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorNEVERCODE          = Vendor{Name: "Nevercode", Constant: "NEVERCODE", Env: syntax.EnvList{{StrictEqual: "NEVERCODE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "NEVERCODE_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorPROW               = Vendor{Name: "Prow", Constant: "PROW", Env: syntax.EnvList{{StrictEqual: "PROW_JOB_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorRELEASEHUB         = Vendor{Name: "ReleaseHub", Constant: "RELEASEHUB", Env: syntax.EnvList{{StrictEqual: "RELEASE_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorRENDER             = Vendor{Name: "Render", Constant: "RENDER", Env: syntax.EnvList{{StrictEqual: "RENDER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"IS_PULL_REQUEST": "true"}}}
-	VendorSAIL               = Vendor{Name: "Sail CI", Constant: "SAIL", Env: syntax.EnvList{{StrictEqual: "SAILCI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "SAIL_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorSCREWDRIVER        = Vendor{Name: "Screwdriver", Constant: "SCREWDRIVER", Env: syntax.EnvList{{StrictEqual: "SCREWDRIVER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "SD_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorSEMAPHORE          = Vendor{Name: "Semaphore", Constant: "SEMAPHORE", Env: syntax.EnvList{{StrictEqual: "SEMAPHORE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorSOURCEHUT          = Vendor{Name: "Sourcehut", Constant: "SOURCEHUT", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_NAME": "sourcehut"}}}, PR: nil}
-	VendorSTRIDER            = Vendor{Name: "Strider CD", Constant: "STRIDER", Env: syntax.EnvList{{StrictEqual: "STRIDER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorTASKCLUSTER        = Vendor{Name: "TaskCluster", Constant: "TASKCLUSTER", Env: syntax.EnvList{{StrictEqual: "TASK_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}, {StrictEqual: "RUN_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorTEAMCITY           = Vendor{Name: "TeamCity", Constant: "TEAMCITY", Env: syntax.EnvList{{StrictEqual: "TEAMCITY_VERSION", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorTRAVIS             = Vendor{Name: "Travis CI", Constant: "TRAVIS", Env: syntax.EnvList{{StrictEqual: "TRAVIS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "TRAVIS_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorVELA               = Vendor{Name: "Vela", Constant: "VELA", Env: syntax.EnvList{{StrictEqual: "VELA", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"VELA_PULL_REQUEST": "1"}}}
-	VendorVERCEL             = Vendor{Name: "Vercel", Constant: "VERCEL", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string{"NOW_BUILDER", "VERCEL"}, EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "VERCEL_GIT_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorAPPCENTER          = Vendor{Name: "Visual Studio App Center", Constant: "APPCENTER", Env: syntax.EnvList{{StrictEqual: "APPCENTER_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
-	VendorWOODPECKER         = Vendor{Name: "Woodpecker", Constant: "WOODPECKER", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI": "woodpecker"}}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_BUILD_EVENT": "pull_request"}}}
-	VendorXCODE_CLOUD        = Vendor{Name: "Xcode Cloud", Constant: "XCODE_CLOUD", Env: syntax.EnvList{{StrictEqual: "CI_XCODE_PROJECT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CI_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}
-	VendorXCODE_SERVER       = Vendor{Name: "Xcode Server", Constant: "XCODE_SERVER", Env: syntax.EnvList{{StrictEqual: "XCS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil}
+	VendorAGOLA              = extend(Vendor{Name: "Agola CI", Constant: "AGOLA", Env: syntax.EnvList{{StrictEqual: "AGOLA_GIT_REF", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "AGOLA_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorALPIC              = extend(Vendor{Name: "Alpic", Constant: "ALPIC", Env: syntax.EnvList{{StrictEqual: "ALPIC_HOST", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorAPPCIRCLE          = extend(Vendor{Name: "Appcircle", Constant: "APPCIRCLE", Env: syntax.EnvList{{StrictEqual: "AC_APPCIRCLE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "AC_GIT_PR", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorAPPVEYOR           = extend(Vendor{Name: "AppVeyor", Constant: "APPVEYOR", Env: syntax.EnvList{{StrictEqual: "APPVEYOR", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "APPVEYOR_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorCODEBUILD          = extend(Vendor{Name: "AWS CodeBuild", Constant: "CODEBUILD", Env: syntax.EnvList{{StrictEqual: "CODEBUILD_BUILD_ARN", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CODEBUILD_WEBHOOK_EVENT", NotEqual: "", EqualsAnyOf: []string{"PULL_REQUEST_CREATED", "PULL_REQUEST_UPDATED", "PULL_REQUEST_REOPENED"}, EqualsMap: map[string]string(nil)}})
+	VendorAZURE_PIPELINES    = extend(Vendor{Name: "Azure Pipelines", Constant: "AZURE_PIPELINES", Env: syntax.EnvList{{StrictEqual: "TF_BUILD", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"BUILD_REASON": "PullRequest"}}})
+	VendorBAMBOO             = extend(Vendor{Name: "Bamboo", Constant: "BAMBOO", Env: syntax.EnvList{{StrictEqual: "bamboo_planKey", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorBITBUCKET          = extend(Vendor{Name: "Bitbucket Pipelines", Constant: "BITBUCKET", Env: syntax.EnvList{{StrictEqual: "BITBUCKET_COMMIT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BITBUCKET_PR_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorBITRISE            = extend(Vendor{Name: "Bitrise", Constant: "BITRISE", Env: syntax.EnvList{{StrictEqual: "BITRISE_IO", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BITRISE_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorBUDDY              = extend(Vendor{Name: "Buddy", Constant: "BUDDY", Env: syntax.EnvList{{StrictEqual: "BUDDY_WORKSPACE_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BUDDY_EXECUTION_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorBUILDKITE          = extend(Vendor{Name: "Buildkite", Constant: "BUILDKITE", Env: syntax.EnvList{{StrictEqual: "BUILDKITE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "BUILDKITE_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorCIRCLE             = extend(Vendor{Name: "CircleCI", Constant: "CIRCLE", Env: syntax.EnvList{{StrictEqual: "CIRCLECI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CIRCLE_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorCIRRUS             = extend(Vendor{Name: "Cirrus CI", Constant: "CIRRUS", Env: syntax.EnvList{{StrictEqual: "CIRRUS_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CIRRUS_PR", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorCLOUDFLARE_PAGES   = extend(Vendor{Name: "Cloudflare Pages", Constant: "CLOUDFLARE_PAGES", Env: syntax.EnvList{{StrictEqual: "CF_PAGES", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorCLOUDFLARE_WORKERS = extend(Vendor{Name: "Cloudflare Workers", Constant: "CLOUDFLARE_WORKERS", Env: syntax.EnvList{{StrictEqual: "WORKERS_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorCODEFRESH          = extend(Vendor{Name: "Codefresh", Constant: "CODEFRESH", Env: syntax.EnvList{{StrictEqual: "CF_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string{"CF_PULL_REQUEST_NUMBER", "CF_PULL_REQUEST_ID"}, EqualsMap: map[string]string(nil)}})
+	VendorCODEMAGIC          = extend(Vendor{Name: "Codemagic", Constant: "CODEMAGIC", Env: syntax.EnvList{{StrictEqual: "CM_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CM_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorCODESHIP           = extend(Vendor{Name: "Codeship", Constant: "CODESHIP", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_NAME": "codeship"}}}, PR: nil})
+	VendorDRONE              = extend(Vendor{Name: "Drone", Constant: "DRONE", Env: syntax.EnvList{{StrictEqual: "DRONE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"DRONE_BUILD_EVENT": "pull_request"}}})
+	VendorDSARI              = extend(Vendor{Name: "dsari", Constant: "DSARI", Env: syntax.EnvList{{StrictEqual: "DSARI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorEARTHLY            = extend(Vendor{Name: "Earthly", Constant: "EARTHLY", Env: syntax.EnvList{{StrictEqual: "EARTHLY_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorEAS                = extend(Vendor{Name: "Expo Application Services", Constant: "EAS", Env: syntax.EnvList{{StrictEqual: "EAS_BUILD", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorGERRIT             = extend(Vendor{Name: "Gerrit", Constant: "GERRIT", Env: syntax.EnvList{{StrictEqual: "GERRIT_PROJECT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorGITEA_ACTIONS      = extend(Vendor{Name: "Gitea Actions", Constant: "GITEA_ACTIONS", Env: syntax.EnvList{{StrictEqual: "GITEA_ACTIONS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorGITHUB_ACTIONS     = extend(Vendor{Name: "GitHub Actions", Constant: "GITHUB_ACTIONS", Env: syntax.EnvList{{StrictEqual: "GITHUB_ACTIONS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"GITHUB_EVENT_NAME": "pull_request"}}})
+	VendorGITLAB             = extend(Vendor{Name: "GitLab CI", Constant: "GITLAB", Env: syntax.EnvList{{StrictEqual: "GITLAB_CI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CI_MERGE_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorGOCD               = extend(Vendor{Name: "GoCD", Constant: "GOCD", Env: syntax.EnvList{{StrictEqual: "GO_PIPELINE_LABEL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorGOOGLE_CLOUD_BUILD = extend(Vendor{Name: "Google Cloud Build", Constant: "GOOGLE_CLOUD_BUILD", Env: syntax.EnvList{{StrictEqual: "BUILDER_OUTPUT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorHARNESS            = extend(Vendor{Name: "Harness CI", Constant: "HARNESS", Env: syntax.EnvList{{StrictEqual: "HARNESS_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorHEROKU             = extend(Vendor{Name: "Heroku", Constant: "HEROKU", Env: syntax.EnvList{{StrictEqual: "NODE", Includes: "/app/.heroku/node/bin/node", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorHUDSON             = extend(Vendor{Name: "Hudson", Constant: "HUDSON", Env: syntax.EnvList{{StrictEqual: "HUDSON_URL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorJENKINS            = extend(Vendor{Name: "Jenkins", Constant: "JENKINS", Env: syntax.EnvList{{StrictEqual: "JENKINS_URL", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}, {StrictEqual: "BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string{"ghprbPullId", "CHANGE_ID"}, EqualsMap: map[string]string(nil)}})
+	VendorLAYERCI            = extend(Vendor{Name: "LayerCI", Constant: "LAYERCI", Env: syntax.EnvList{{StrictEqual: "LAYERCI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "LAYERCI_PULL_REQUEST", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorMAGNUM             = extend(Vendor{Name: "Magnum CI", Constant: "MAGNUM", Env: syntax.EnvList{{StrictEqual: "MAGNUM", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorNETLIFY            = extend(Vendor{Name: "Netlify CI", Constant: "NETLIFY", Env: syntax.EnvList{{StrictEqual: "NETLIFY", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorNEVERCODE          = extend(Vendor{Name: "Nevercode", Constant: "NEVERCODE", Env: syntax.EnvList{{StrictEqual: "NEVERCODE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "NEVERCODE_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorPROW               = extend(Vendor{Name: "Prow", Constant: "PROW", Env: syntax.EnvList{{StrictEqual: "PROW_JOB_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorRELEASEHUB         = extend(Vendor{Name: "ReleaseHub", Constant: "RELEASEHUB", Env: syntax.EnvList{{StrictEqual: "RELEASE_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorRENDER             = extend(Vendor{Name: "Render", Constant: "RENDER", Env: syntax.EnvList{{StrictEqual: "RENDER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"IS_PULL_REQUEST": "true"}}})
+	VendorSAIL               = extend(Vendor{Name: "Sail CI", Constant: "SAIL", Env: syntax.EnvList{{StrictEqual: "SAILCI", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "SAIL_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorSCREWDRIVER        = extend(Vendor{Name: "Screwdriver", Constant: "SCREWDRIVER", Env: syntax.EnvList{{StrictEqual: "SCREWDRIVER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "SD_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorSEMAPHORE          = extend(Vendor{Name: "Semaphore", Constant: "SEMAPHORE", Env: syntax.EnvList{{StrictEqual: "SEMAPHORE", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorSOURCEHUT          = extend(Vendor{Name: "Sourcehut", Constant: "SOURCEHUT", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_NAME": "sourcehut"}}}, PR: nil})
+	VendorSTRIDER            = extend(Vendor{Name: "Strider CD", Constant: "STRIDER", Env: syntax.EnvList{{StrictEqual: "STRIDER", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorTASKCLUSTER        = extend(Vendor{Name: "TaskCluster", Constant: "TASKCLUSTER", Env: syntax.EnvList{{StrictEqual: "TASK_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}, {StrictEqual: "RUN_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorTEAMCITY           = extend(Vendor{Name: "TeamCity", Constant: "TEAMCITY", Env: syntax.EnvList{{StrictEqual: "TEAMCITY_VERSION", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorTRAVIS             = extend(Vendor{Name: "Travis CI", Constant: "TRAVIS", Env: syntax.EnvList{{StrictEqual: "TRAVIS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "TRAVIS_PULL_REQUEST", NotEqual: "false", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorVELA               = extend(Vendor{Name: "Vela", Constant: "VELA", Env: syntax.EnvList{{StrictEqual: "VELA", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"VELA_PULL_REQUEST": "1"}}})
+	VendorVERCEL             = extend(Vendor{Name: "Vercel", Constant: "VERCEL", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string{"NOW_BUILDER", "VERCEL"}, EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "VERCEL_GIT_PULL_REQUEST_ID", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorAPPCENTER          = extend(Vendor{Name: "Visual Studio App Center", Constant: "APPCENTER", Env: syntax.EnvList{{StrictEqual: "APPCENTER_BUILD_ID", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
+	VendorWOODPECKER         = extend(Vendor{Name: "Woodpecker", Constant: "WOODPECKER", Env: syntax.EnvList{{StrictEqual: "", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI": "woodpecker"}}}, PR: &syntax.PR{StrictEqual: "", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string{"CI_BUILD_EVENT": "pull_request"}}})
+	VendorXCODE_CLOUD        = extend(Vendor{Name: "Xcode Cloud", Constant: "XCODE_CLOUD", Env: syntax.EnvList{{StrictEqual: "CI_XCODE_PROJECT", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: &syntax.PR{StrictEqual: "CI_PULL_REQUEST_NUMBER", NotEqual: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}})
+	VendorXCODE_SERVER       = extend(Vendor{Name: "Xcode Server", Constant: "XCODE_SERVER", Env: syntax.EnvList{{StrictEqual: "XCS", Includes: "", EqualsAnyOf: []string(nil), EqualsMap: map[string]string(nil)}}, PR: nil})
 	All                      = []Vendor{VendorAGOLA, VendorALPIC, VendorAPPCIRCLE, VendorAPPVEYOR, VendorCODEBUILD, VendorAZURE_PIPELINES, VendorBAMBOO, VendorBITBUCKET, VendorBITRISE, VendorBUDDY, VendorBUILDKITE, VendorCIRCLE, VendorCIRRUS, VendorCLOUDFLARE_PAGES, VendorCLOUDFLARE_WORKERS, VendorCODEFRESH, VendorCODEMAGIC, VendorCODESHIP, VendorDRONE, VendorDSARI, VendorEARTHLY, VendorEAS, VendorGERRIT, VendorGITEA_ACTIONS, VendorGITHUB_ACTIONS, VendorGITLAB, VendorGOCD, VendorGOOGLE_CLOUD_BUILD, VendorHARNESS, VendorHEROKU, VendorHUDSON, VendorJENKINS, VendorLAYERCI, VendorMAGNUM, VendorNETLIFY, VendorNEVERCODE, VendorPROW, VendorRELEASEHUB, VendorRENDER, VendorSAIL, VendorSCREWDRIVER, VendorSEMAPHORE, VendorSOURCEHUT, VendorSTRIDER, VendorTASKCLUSTER, VendorTEAMCITY, VendorTRAVIS, VendorVELA, VendorVERCEL, VendorAPPCENTER, VendorWOODPECKER, VendorXCODE_CLOUD, VendorXCODE_SERVER}
 )
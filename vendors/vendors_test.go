@@ -0,0 +1,72 @@
+package vendors
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/startracex/ciinfo/syntax"
+)
+
+func TestVendorJSONRoundTrip(t *testing.T) {
+	for _, v := range All {
+		t.Run(v.Constant, func(t *testing.T) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var got Vendor
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, v) {
+				t.Errorf("round trip mismatch\n got: %+v\nwant: %+v\njson: %s", got, v, data)
+			}
+		})
+	}
+}
+
+type solvable interface {
+	Match(env syntax.Environment) bool
+	Satisfy() (map[string]string, bool)
+	Violate() (map[string]string, bool)
+}
+
+func TestVendorRulesSatisfiable(t *testing.T) {
+	check := func(name string, r solvable) {
+		if env, ok := r.Satisfy(); !ok || !r.Match(syntax.Map(env)) {
+			t.Errorf("%s: no environment matches", name)
+		}
+		if env, ok := r.Violate(); !ok || r.Match(syntax.Map(env)) {
+			t.Errorf("%s: every environment matches", name)
+		}
+	}
+	for _, v := range All {
+		check(v.Constant+" env", &v.Env)
+		if v.PR != nil {
+			check(v.Constant+" pr", v.PR)
+		}
+		for _, r := range v.Events {
+			check(v.Constant+" event "+string(r.Event), &r.When)
+		}
+	}
+}
+
+func TestVendorRulesValidate(t *testing.T) {
+	for _, v := range All {
+		if err := v.Env.Validate(); err != nil {
+			t.Errorf("%s env: %v", v.Constant, err)
+		}
+		if v.PR != nil {
+			if err := v.PR.Validate(); err != nil {
+				t.Errorf("%s pr: %v", v.Constant, err)
+			}
+		}
+		for _, r := range v.Events {
+			if err := r.When.Validate(); err != nil {
+				t.Errorf("%s event %s: %v", v.Constant, r.Event, err)
+			}
+		}
+	}
+}